@@ -0,0 +1,191 @@
+// Package questions memvalidasi pertanyaan tambahan per lowongan (entity.ApplicationQuestion)
+// beserta jawaban pendaftar terhadap skema pertanyaan tersebut.
+package questions
+
+import (
+	"fmt"
+	"miniproject/constants"
+	"miniproject/entity"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// MaxQuestions adalah jumlah pertanyaan tambahan maksimum per lowongan.
+	MaxQuestions = 20
+	// DefaultMaxLength adalah panjang jawaban text maksimum jika MaxLength tidak diisi.
+	DefaultMaxLength = 1000
+)
+
+var keyPattern = regexp.MustCompile(`^[a-z0-9_]{1,64}$`)
+
+// IsKnownType memeriksa apakah jenis pertanyaan didukung.
+func IsKnownType(questionType string) bool {
+	switch questionType {
+	case constants.QuestionText, constants.QuestionChoice, constants.QuestionNumber, constants.QuestionFile:
+		return true
+	}
+	return false
+}
+
+// Find mengembalikan pertanyaan dengan key tertentu.
+func Find(questions []entity.ApplicationQuestion, key string) (*entity.ApplicationQuestion, bool) {
+	for i := range questions {
+		if questions[i].Key == key {
+			return &questions[i], true
+		}
+	}
+	return nil, false
+}
+
+// Validate memeriksa skema pertanyaan sebelum disimpan dan mengembalikan field yang tidak valid.
+func Validate(questions []entity.ApplicationQuestion) map[string]string {
+	invalidData := make(map[string]string)
+	if len(questions) > MaxQuestions {
+		invalidData["questions"] = fmt.Sprintf("Jumlah pertanyaan maksimum %d", MaxQuestions)
+		return invalidData
+	}
+
+	keys := make(map[string]bool, len(questions))
+	for i, question := range questions {
+		field := fmt.Sprintf("questions[%d]", i)
+		if !keyPattern.MatchString(question.Key) {
+			invalidData[field+".key"] = "Key hanya boleh berisi huruf kecil, angka, dan garis bawah (maksimal 64 karakter)"
+		} else if keys[question.Key] {
+			invalidData[field+".key"] = "Key pertanyaan harus unik"
+		}
+		keys[question.Key] = true
+		if strings.TrimSpace(question.Label) == "" {
+			invalidData[field+".label"] = "Label pertanyaan wajib diisi"
+		}
+		if !IsKnownType(question.Type) {
+			invalidData[field+".type"] = "Jenis pertanyaan harus text, choice, number, atau file"
+			continue
+		}
+
+		switch question.Type {
+		case constants.QuestionText:
+			if question.MaxLength < 0 {
+				invalidData[field+".max_length"] = "Panjang maksimum tidak boleh negatif"
+			}
+		case constants.QuestionChoice:
+			if len(question.Options) < 2 {
+				invalidData[field+".options"] = "Pertanyaan pilihan membutuhkan minimal dua opsi"
+				break
+			}
+			options := make(map[string]bool, len(question.Options))
+			for _, option := range question.Options {
+				if strings.TrimSpace(option) == "" || options[option] {
+					invalidData[field+".options"] = "Opsi tidak boleh kosong atau ganda"
+					break
+				}
+				options[option] = true
+			}
+		case constants.QuestionNumber:
+			if question.Min != nil && question.Max != nil && *question.Min > *question.Max {
+				invalidData[field+".max"] = "Nilai maksimum tidak boleh lebih kecil dari nilai minimum"
+			}
+		}
+	}
+	return invalidData
+}
+
+// Check memvalidasi jawaban terhadap skema pertanyaan lowongan dan mengembalikan jawaban yang
+// sudah dinormalisasi (urut sesuai pertanyaan, dengan Label dan Type dari skema). Pertanyaan file
+// tidak dijawab di sini karena berkasnya diunggah setelah formulir tersimpan.
+func Check(questions []entity.ApplicationQuestion, answers []entity.ApplicationAnswer) ([]entity.ApplicationAnswer, map[string]string) {
+	return check(questions, answers, true)
+}
+
+// CheckDraft sama seperti Check tetapi tidak mewajibkan pertanyaan required dijawab, untuk
+// menyimpan draft yang belum lengkap.
+func CheckDraft(questions []entity.ApplicationQuestion, answers []entity.ApplicationAnswer) ([]entity.ApplicationAnswer, map[string]string) {
+	return check(questions, answers, false)
+}
+
+func check(questions []entity.ApplicationQuestion, answers []entity.ApplicationAnswer, requireAll bool) ([]entity.ApplicationAnswer, map[string]string) {
+	invalidData := make(map[string]string)
+	byKey := make(map[string]entity.ApplicationAnswer, len(answers))
+	for _, answer := range answers {
+		field := "answers." + answer.Key
+		question, ok := Find(questions, answer.Key)
+		switch {
+		case !ok:
+			invalidData[field] = "Pertanyaan tidak dikenal"
+		case question.Type == constants.QuestionFile:
+			invalidData[field] = "Jawaban berkas diunggah melalui endpoint jawaban formulir"
+		default:
+			if _, duplicate := byKey[answer.Key]; duplicate {
+				invalidData[field] = "Pertanyaan hanya boleh dijawab sekali"
+			}
+		}
+		byKey[answer.Key] = answer
+	}
+
+	var normalized []entity.ApplicationAnswer
+	for _, question := range questions {
+		if question.Type == constants.QuestionFile {
+			continue
+		}
+		field := "answers." + question.Key
+		if _, invalid := invalidData[field]; invalid {
+			continue
+		}
+		answer, answered := byKey[question.Key]
+		answer.Text = strings.TrimSpace(answer.Text)
+		if !answered || (answer.Text == "" && answer.Number == nil) {
+			if requireAll && question.Required {
+				invalidData[field] = "Pertanyaan wajib dijawab"
+			}
+			continue
+		}
+
+		if message := checkAnswer(question, answer); message != "" {
+			invalidData[field] = message
+			continue
+		}
+		normalized = append(normalized, entity.ApplicationAnswer{
+			Key:    question.Key,
+			Label:  question.Label,
+			Type:   question.Type,
+			Text:   answer.Text,
+			Number: answer.Number,
+		})
+	}
+	return normalized, invalidData
+}
+
+func checkAnswer(question entity.ApplicationQuestion, answer entity.ApplicationAnswer) string {
+	switch question.Type {
+	case constants.QuestionText:
+		maxLength := question.MaxLength
+		if maxLength == 0 {
+			maxLength = DefaultMaxLength
+		}
+		if answer.Number != nil {
+			return "Jawaban harus berupa teks"
+		}
+		if utf8.RuneCountInString(answer.Text) > maxLength {
+			return fmt.Sprintf("Jawaban maksimal %d karakter", maxLength)
+		}
+	case constants.QuestionChoice:
+		for _, option := range question.Options {
+			if answer.Text == option {
+				return ""
+			}
+		}
+		return "Jawaban harus salah satu dari: " + strings.Join(question.Options, ", ")
+	case constants.QuestionNumber:
+		if answer.Number == nil {
+			return "Jawaban harus berupa angka"
+		}
+		if question.Min != nil && *answer.Number < *question.Min {
+			return fmt.Sprintf("Jawaban tidak boleh kurang dari %g", *question.Min)
+		}
+		if question.Max != nil && *answer.Number > *question.Max {
+			return fmt.Sprintf("Jawaban tidak boleh lebih dari %g", *question.Max)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,80 @@
+package questions
+
+import (
+	"miniproject/constants"
+	"miniproject/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func float(value float64) *float64 {
+	return &value
+}
+
+var schema = []entity.ApplicationQuestion{
+	{Key: "motivation", Label: "Motivasi", Type: constants.QuestionText, Required: true, MaxLength: 20},
+	{Key: "division", Label: "Divisi", Type: constants.QuestionChoice, Options: []string{"Backend", "Frontend"}},
+	{Key: "semester", Label: "Semester", Type: constants.QuestionNumber, Required: true, Min: float(5), Max: float(8)},
+	{Key: "portfolio", Label: "Portofolio", Type: constants.QuestionFile, Required: true},
+}
+
+func TestValidate(t *testing.T) {
+	assert.Empty(t, Validate(schema))
+
+	invalidData := Validate([]entity.ApplicationQuestion{
+		{Key: "Motivasi Anda", Label: "Motivasi", Type: constants.QuestionText},
+		{Key: "division", Label: "", Type: constants.QuestionChoice, Options: []string{"Backend"}},
+		{Key: "division", Label: "Divisi", Type: "checkbox"},
+		{Key: "semester", Label: "Semester", Type: constants.QuestionNumber, Min: float(8), Max: float(5)},
+	})
+	assert.Contains(t, invalidData, "questions[0].key")
+	assert.Contains(t, invalidData, "questions[1].label")
+	assert.Contains(t, invalidData, "questions[1].options")
+	assert.Equal(t, "Key pertanyaan harus unik", invalidData["questions[2].key"])
+	assert.Contains(t, invalidData, "questions[2].type")
+	assert.Contains(t, invalidData, "questions[3].max")
+}
+
+func TestCheck(t *testing.T) {
+	answers, invalidData := Check(schema, []entity.ApplicationAnswer{
+		{Key: "semester", Number: float(6)},
+		{Key: "motivation", Text: "  Belajar Go  "},
+	})
+	assert.Empty(t, invalidData)
+	if assert.Len(t, answers, 2) {
+		assert.Equal(t, entity.ApplicationAnswer{Key: "motivation", Label: "Motivasi", Type: constants.QuestionText, Text: "Belajar Go"}, answers[0])
+		assert.Equal(t, "semester", answers[1].Key)
+	}
+}
+
+func TestCheckRejectsInvalidAnswers(t *testing.T) {
+	_, invalidData := Check(schema, []entity.ApplicationAnswer{
+		{Key: "motivation", Text: "jawaban ini terlalu panjang"},
+		{Key: "division", Text: "Mobile"},
+		{Key: "semester", Number: float(3)},
+		{Key: "portfolio", Text: "https://example.com"},
+		{Key: "hobby", Text: "Membaca"},
+	})
+	assert.Equal(t, "Jawaban maksimal 20 karakter", invalidData["answers.motivation"])
+	assert.Contains(t, invalidData["answers.division"], "Backend, Frontend")
+	assert.Equal(t, "Jawaban tidak boleh kurang dari 5", invalidData["answers.semester"])
+	assert.Contains(t, invalidData, "answers.portfolio")
+	assert.Equal(t, "Pertanyaan tidak dikenal", invalidData["answers.hobby"])
+
+	// Pertanyaan wajib harus dijawab, kecuali pertanyaan file yang diunggah terpisah
+	_, invalidData = Check(schema, nil)
+	assert.Equal(t, map[string]string{
+		"answers.motivation": "Pertanyaan wajib dijawab",
+		"answers.semester":   "Pertanyaan wajib dijawab",
+	}, invalidData)
+}
+
+func TestCheckDraft(t *testing.T) {
+	answers, invalidData := CheckDraft(schema, []entity.ApplicationAnswer{{Key: "division", Text: "Backend"}})
+	assert.Empty(t, invalidData)
+	assert.Len(t, answers, 1)
+
+	_, invalidData = CheckDraft(schema, []entity.ApplicationAnswer{{Key: "semester", Number: float(10)}})
+	assert.Equal(t, "Jawaban tidak boleh lebih dari 8", invalidData["answers.semester"])
+}
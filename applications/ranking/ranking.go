@@ -0,0 +1,95 @@
+// Package ranking mengurutkan kandidat satu lowongan berdasarkan bobot kriteria yang dapat
+// diatur per lowongan (entity.RankingWeights).
+package ranking
+
+import (
+	"miniproject/applications/screening"
+	"miniproject/entity"
+	"sort"
+	"strings"
+)
+
+// DefaultWeights dipakai jika lowongan maupun permintaan tidak menentukan bobot.
+func DefaultWeights() entity.RankingWeights {
+	return entity.RankingWeights{GPA: 1}
+}
+
+// Ranked adalah kandidat beserta skor dan peringkatnya (dimulai dari 1).
+type Ranked struct {
+	Application *entity.Internship_ApplicationForm
+	Score       float64
+	Rank        int
+}
+
+// Rank menghitung skor setiap kandidat lalu mengurutkannya dari skor tertinggi. Skor yang sama
+// diurutkan berdasarkan waktu pendaftaran sehingga hasilnya selalu deterministik.
+func Rank(weights *entity.RankingWeights, applications []*entity.Internship_ApplicationForm) []Ranked {
+	if weights == nil {
+		defaults := DefaultWeights()
+		weights = &defaults
+	}
+
+	// Urutan pendaftaran dipakai untuk kriteria early_apply dan sebagai pemecah skor seri
+	byApplyOrder := make([]*entity.Internship_ApplicationForm, len(applications))
+	copy(byApplyOrder, applications)
+	sort.SliceStable(byApplyOrder, func(i, j int) bool { return byApplyOrder[i].ID < byApplyOrder[j].ID })
+	applyOrder := make(map[uint]int, len(byApplyOrder))
+	for i, application := range byApplyOrder {
+		applyOrder[application.ID] = i
+	}
+
+	ranked := make([]Ranked, 0, len(applications))
+	for _, application := range byApplyOrder {
+		ranked = append(ranked, Ranked{
+			Application: application,
+			Score:       score(weights, application, applyOrder[application.ID], len(applications)),
+		})
+	}
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].Score > ranked[j].Score })
+	for i := range ranked {
+		ranked[i].Rank = i + 1
+	}
+	return ranked
+}
+
+func score(weights *entity.RankingWeights, application *entity.Internship_ApplicationForm, order, total int) float64 {
+	value := weights.GPA * application.GPA / screening.MaxGPAScale
+	if screening.Facts(application)[screening.FactHasCV] {
+		value += weights.HasCV
+	}
+	if total > 1 {
+		value += weights.EarlyApply * float64(total-1-order) / float64(total-1)
+	} else {
+		value += weights.EarlyApply
+	}
+	for level, weight := range weights.EducationLevels {
+		if strings.EqualFold(strings.TrimSpace(level), strings.TrimSpace(application.EducationLevel)) {
+			value += weight
+			break
+		}
+	}
+	return value
+}
+
+// Validate memeriksa bobot sebelum disimpan dan mengembalikan field yang tidak valid.
+func Validate(weights *entity.RankingWeights) map[string]string {
+	invalidData := make(map[string]string)
+	if weights == nil {
+		return invalidData
+	}
+	if weights.GPA < 0 {
+		invalidData["ranking_weights.gpa"] = "Bobot tidak boleh negatif"
+	}
+	if weights.HasCV < 0 {
+		invalidData["ranking_weights.has_cv"] = "Bobot tidak boleh negatif"
+	}
+	if weights.EarlyApply < 0 {
+		invalidData["ranking_weights.early_apply"] = "Bobot tidak boleh negatif"
+	}
+	for level, weight := range weights.EducationLevels {
+		if weight < 0 {
+			invalidData["ranking_weights.education_levels."+level] = "Bobot tidak boleh negatif"
+		}
+	}
+	return invalidData
+}
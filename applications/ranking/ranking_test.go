@@ -0,0 +1,54 @@
+package ranking
+
+import (
+	"miniproject/constants"
+	"miniproject/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func application(id uint, gpa float64, level, cv string) *entity.Internship_ApplicationForm {
+	form := &entity.Internship_ApplicationForm{GPA: gpa, EducationLevel: level}
+	form.ID = id
+	if cv != "" {
+		form.Documents = []entity.ApplicationDocument{{Type: constants.DocumentCV, FileName: cv}}
+	}
+	return form
+}
+
+func ids(ranked []Ranked) []uint {
+	var result []uint
+	for _, candidate := range ranked {
+		result = append(result, candidate.Application.ID)
+	}
+	return result
+}
+
+func TestRankDefaultWeights(t *testing.T) {
+	applications := []*entity.Internship_ApplicationForm{
+		application(1, 3.2, "S1", ""),
+		application(2, 3.8, "S1", ""),
+		application(3, 3.8, "S1", ""),
+	}
+
+	ranked := Rank(nil, applications)
+	// Skor seri diurutkan berdasarkan waktu pendaftaran
+	assert.Equal(t, []uint{2, 3, 1}, ids(ranked))
+	assert.Equal(t, 1, ranked[0].Rank)
+}
+
+func TestRankWeightedCriteria(t *testing.T) {
+	applications := []*entity.Internship_ApplicationForm{
+		application(1, 3.9, "D3", ""),
+		application(2, 3.5, "S1", "cv.pdf"),
+	}
+	weights := &entity.RankingWeights{GPA: 1, HasCV: 0.2, EducationLevels: map[string]float64{"s1": 0.1}}
+
+	assert.Equal(t, []uint{2, 1}, ids(Rank(weights, applications)))
+}
+
+func TestValidate(t *testing.T) {
+	assert.Empty(t, Validate(&entity.RankingWeights{GPA: 1, EarlyApply: 0.5}))
+	assert.Contains(t, Validate(&entity.RankingWeights{GPA: -1}), "ranking_weights.gpa")
+}
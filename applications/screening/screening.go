@@ -0,0 +1,171 @@
+// Package screening mengevaluasi formulir pendaftaran terhadap kriteria penyaringan lowongan
+// dan mengembalikan alasan lolos/gagal untuk setiap aturan sehingga admin dapat menjelaskan
+// keputusan seleksi kepada kandidat.
+package screening
+
+import (
+	"fmt"
+	"miniproject/constants"
+	"miniproject/entity"
+	"strings"
+)
+
+// Fakta boolean tentang pendaftar yang dapat dipakai pada entity.ScreeningRule.
+const (
+	FactHasCV = "has_cv"
+)
+
+// Nama aturan bawaan yang muncul pada Reason.Rule.
+const (
+	RuleMinGPA         = "min_gpa"
+	RuleMaxGPA         = "max_gpa"
+	RuleEducationLevel = "education_level"
+	RuleMajor          = "major"
+)
+
+// MaxGPAScale adalah nilai IPK tertinggi yang mungkin.
+const MaxGPAScale = 4.0
+
+// Reason adalah hasil evaluasi satu aturan.
+type Reason struct {
+	Rule    string `json:"rule"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message"`
+}
+
+// Result adalah hasil evaluasi seluruh kriteria. Passed bernilai true jika semua aturan lolos.
+type Result struct {
+	Passed  bool     `json:"passed"`
+	Reasons []Reason `json:"reasons"`
+}
+
+// DefaultCriteria dipakai untuk lowongan yang belum memiliki kriteria sendiri.
+func DefaultCriteria() entity.ScreeningCriteria {
+	return entity.ScreeningCriteria{MinGPA: 3.5, MaxGPA: MaxGPAScale}
+}
+
+// Facts mengumpulkan fakta boolean tentang pendaftar dari formulir dan dokumen yang diunggahnya.
+func Facts(application *entity.Internship_ApplicationForm) map[string]bool {
+	hasCV := false
+	for _, document := range application.Documents {
+		if document.Type == constants.DocumentCV {
+			hasCV = true
+			break
+		}
+	}
+	return map[string]bool{
+		FactHasCV: hasCV,
+	}
+}
+
+// IsKnownFact memeriksa apakah fakta dapat dipakai pada aturan kustom.
+func IsKnownFact(fact string) bool {
+	_, ok := Facts(&entity.Internship_ApplicationForm{})[fact]
+	return ok
+}
+
+// Evaluate memeriksa formulir terhadap kriteria. Kriteria nil diganti DefaultCriteria.
+func Evaluate(criteria *entity.ScreeningCriteria, application *entity.Internship_ApplicationForm) Result {
+	if criteria == nil {
+		defaults := DefaultCriteria()
+		criteria = &defaults
+	}
+
+	result := Result{Passed: true}
+	add := func(rule string, passed bool, format string, args ...interface{}) {
+		result.Reasons = append(result.Reasons, Reason{Rule: rule, Passed: passed, Message: fmt.Sprintf(format, args...)})
+		result.Passed = result.Passed && passed
+	}
+
+	if criteria.MinGPA > 0 {
+		if application.GPA >= criteria.MinGPA {
+			add(RuleMinGPA, true, "IPK %.2f memenuhi minimum %.2f", application.GPA, criteria.MinGPA)
+		} else {
+			add(RuleMinGPA, false, "IPK %.2f di bawah minimum %.2f", application.GPA, criteria.MinGPA)
+		}
+	}
+	if criteria.MaxGPA > 0 {
+		if application.GPA <= criteria.MaxGPA {
+			add(RuleMaxGPA, true, "IPK %.2f tidak melebihi maksimum %.2f", application.GPA, criteria.MaxGPA)
+		} else {
+			add(RuleMaxGPA, false, "IPK %.2f melebihi maksimum %.2f", application.GPA, criteria.MaxGPA)
+		}
+	}
+	if len(criteria.EducationLevels) > 0 {
+		if containsFold(criteria.EducationLevels, application.EducationLevel) {
+			add(RuleEducationLevel, true, "Jenjang pendidikan %s diterima", application.EducationLevel)
+		} else {
+			add(RuleEducationLevel, false, "Jenjang pendidikan %q tidak termasuk %s",
+				application.EducationLevel, strings.Join(criteria.EducationLevels, ", "))
+		}
+	}
+	if len(criteria.Majors) > 0 {
+		if containsFold(criteria.Majors, application.Major) {
+			add(RuleMajor, true, "Jurusan %s diterima", application.Major)
+		} else {
+			add(RuleMajor, false, "Jurusan %q tidak termasuk %s", application.Major, strings.Join(criteria.Majors, ", "))
+		}
+	}
+
+	facts := Facts(application)
+	for _, rule := range criteria.Rules {
+		name := rule.Name
+		if name == "" {
+			name = rule.Fact
+		}
+		value, ok := facts[rule.Fact]
+		switch {
+		case !ok:
+			add(name, false, "Fakta %q tidak dikenal", rule.Fact)
+		case value == rule.Expected:
+			add(name, true, "%s bernilai %t sesuai syarat", rule.Fact, value)
+		default:
+			add(name, false, "%s bernilai %t, seharusnya %t", rule.Fact, value, rule.Expected)
+		}
+	}
+	return result
+}
+
+// FailureSummary menggabungkan pesan aturan yang gagal, cocok untuk alasan penolakan.
+func (r Result) FailureSummary() string {
+	var messages []string
+	for _, reason := range r.Reasons {
+		if !reason.Passed {
+			messages = append(messages, reason.Message)
+		}
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Validate memeriksa kriteria sebelum disimpan dan mengembalikan field yang tidak valid.
+func Validate(criteria *entity.ScreeningCriteria) map[string]string {
+	invalidData := make(map[string]string)
+	if criteria == nil {
+		return invalidData
+	}
+	if criteria.MinGPA < 0 || criteria.MinGPA > MaxGPAScale {
+		invalidData["screening_criteria.min_gpa"] = "IPK minimum harus antara 0 dan 4"
+	}
+	if criteria.MaxGPA < 0 || criteria.MaxGPA > MaxGPAScale {
+		invalidData["screening_criteria.max_gpa"] = "IPK maksimum harus antara 0 dan 4"
+	}
+	if criteria.MinGPA > 0 && criteria.MaxGPA > 0 && criteria.MinGPA > criteria.MaxGPA {
+		invalidData["screening_criteria.max_gpa"] = "IPK maksimum tidak boleh lebih kecil dari IPK minimum"
+	}
+	for i, rule := range criteria.Rules {
+		if !IsKnownFact(rule.Fact) {
+			invalidData[fmt.Sprintf("screening_criteria.rules[%d].fact", i)] = "Fakta tidak dikenal"
+		}
+	}
+	return invalidData
+}
+
+func containsFold(values []string, value string) bool {
+	value = strings.TrimSpace(value)
+	for _, candidate := range values {
+		if strings.EqualFold(strings.TrimSpace(candidate), value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package screening
+
+import (
+	"miniproject/constants"
+	"miniproject/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateDefaultCriteria(t *testing.T) {
+	result := Evaluate(nil, &entity.Internship_ApplicationForm{GPA: 3.6})
+	assert.True(t, result.Passed)
+
+	result = Evaluate(nil, &entity.Internship_ApplicationForm{GPA: 3.4})
+	assert.False(t, result.Passed)
+	assert.Contains(t, result.FailureSummary(), "di bawah minimum 3.50")
+}
+
+func TestEvaluateReasons(t *testing.T) {
+	criteria := &entity.ScreeningCriteria{
+		MinGPA:          3.0,
+		EducationLevels: []string{"S1", "D4"},
+		Majors:          []string{"Teknik Informatika"},
+		Rules:           []entity.ScreeningRule{{Name: "wajib_cv", Fact: FactHasCV, Expected: true}},
+	}
+
+	result := Evaluate(criteria, &entity.Internship_ApplicationForm{
+		GPA: 3.2, EducationLevel: "s1", Major: "teknik informatika",
+		Documents: []entity.ApplicationDocument{{Type: constants.DocumentCV, FileName: "cv.pdf"}},
+	})
+	assert.True(t, result.Passed)
+	assert.Len(t, result.Reasons, 4)
+
+	// CV lama berupa teks bebas tidak lagi memenuhi has_cv
+	result = Evaluate(criteria, &entity.Internship_ApplicationForm{GPA: 3.2, EducationLevel: "SMA", Major: "Teknik Informatika", CV: "cv.pdf"})
+	assert.False(t, result.Passed)
+	failed := []string{}
+	for _, reason := range result.Reasons {
+		if !reason.Passed {
+			failed = append(failed, reason.Rule)
+		}
+	}
+	assert.Equal(t, []string{RuleEducationLevel, "wajib_cv"}, failed)
+}
+
+func TestValidate(t *testing.T) {
+	assert.Empty(t, Validate(nil))
+	assert.Empty(t, Validate(&entity.ScreeningCriteria{MinGPA: 3, MaxGPA: 4}))
+
+	invalidData := Validate(&entity.ScreeningCriteria{
+		MinGPA: 3.5,
+		MaxGPA: 3.0,
+		Rules:  []entity.ScreeningRule{{Fact: "punya_mobil", Expected: true}},
+	})
+	assert.Contains(t, invalidData, "screening_criteria.max_gpa")
+	assert.Contains(t, invalidData, "screening_criteria.rules[0].fact")
+}
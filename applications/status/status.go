@@ -0,0 +1,53 @@
+// Package status mendefinisikan state machine status formulir pendaftaran magang.
+// Semua perubahan status formulir harus melewati CanTransition agar alurnya konsisten
+// (misalnya verified harus mendahului accepted dan canceled bersifat final).
+package status
+
+import (
+	"errors"
+	"miniproject/constants"
+)
+
+var ErrInvalidTransition = errors.New("Perubahan status formulir tidak diizinkan")
+
+// transitions memetakan setiap status ke status tujuan yang diizinkan.
+// Status tanpa tujuan adalah status final. Draft hanya menjadi pending lewat pengajuan draft
+// (yang sekaligus memakai kuota), bukan lewat perubahan status biasa.
+var transitions = map[string][]string{
+	constants.StatusDraft:      {constants.StatusCanceled},
+	constants.StatusPending:    {constants.StatusVerified, constants.StatusRejected, constants.StatusCanceled},
+	constants.StatusVerified:   {constants.StatusAccepted, constants.StatusWaitlisted, constants.StatusRejected, constants.StatusCanceled},
+	constants.StatusWaitlisted: {constants.StatusAccepted, constants.StatusRejected, constants.StatusCanceled},
+	constants.StatusAccepted:   {constants.StatusCanceled},
+	constants.StatusRejected:   {},
+	constants.StatusCanceled:   {},
+}
+
+// IsValid memeriksa apakah status dikenal oleh state machine.
+func IsValid(status string) bool {
+	_, ok := transitions[status]
+	return ok
+}
+
+// IsTerminal memeriksa apakah status tidak dapat diubah lagi.
+func IsTerminal(status string) bool {
+	return IsValid(status) && len(transitions[status]) == 0
+}
+
+// CanTransition memeriksa apakah formulir boleh berpindah dari status from ke status to.
+func CanTransition(from, to string) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Check mengembalikan ErrInvalidTransition jika perpindahan status tidak diizinkan.
+func Check(from, to string) error {
+	if !CanTransition(from, to) {
+		return ErrInvalidTransition
+	}
+	return nil
+}
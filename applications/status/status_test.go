@@ -0,0 +1,28 @@
+package status
+
+import (
+	"miniproject/constants"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanTransition(t *testing.T) {
+	assert.True(t, CanTransition(constants.StatusPending, constants.StatusVerified))
+	assert.True(t, CanTransition(constants.StatusVerified, constants.StatusAccepted))
+	assert.True(t, CanTransition(constants.StatusAccepted, constants.StatusCanceled))
+	assert.True(t, CanTransition(constants.StatusDraft, constants.StatusCanceled))
+
+	assert.False(t, CanTransition(constants.StatusPending, constants.StatusAccepted))
+	assert.False(t, CanTransition(constants.StatusDraft, constants.StatusPending))
+	assert.False(t, CanTransition(constants.StatusCanceled, constants.StatusPending))
+	assert.False(t, CanTransition(constants.StatusRejected, constants.StatusAccepted))
+	assert.False(t, CanTransition("", constants.StatusPending))
+}
+
+func TestIsTerminal(t *testing.T) {
+	assert.True(t, IsTerminal(constants.StatusCanceled))
+	assert.True(t, IsTerminal(constants.StatusRejected))
+	assert.False(t, IsTerminal(constants.StatusPending))
+	assert.False(t, IsTerminal("Dibatalkan"))
+}
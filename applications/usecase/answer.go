@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"errors"
+	"miniproject/applications/questions"
+	"miniproject/constants"
+	"miniproject/entity"
+	"miniproject/repository"
+)
+
+var (
+	ErrQuestionNotFound  = errors.New("Pertanyaan berkas tidak ditemukan pada penawaran magang ini")
+	ErrApplicationClosed = errors.New("Jawaban berkas hanya dapat diubah selama formulir masih berupa draft")
+)
+
+// GetFileQuestion mengembalikan pertanyaan file dengan key tertentu pada lowongan formulir.
+func (uc *applicationUsecase) GetFileQuestion(application *entity.Internship_ApplicationForm, key string) (*entity.ApplicationQuestion, error) {
+	listing, err := uc.listings.FindByID(application.InternshipListingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrListingNotFound
+		}
+		return nil, err
+	}
+	question, ok := questions.Find(listing.Questions, key)
+	if !ok || question.Type != constants.QuestionFile {
+		return nil, ErrQuestionNotFound
+	}
+	return question, nil
+}
+
+// AttachFileAnswer menyimpan dokumen yang sudah diunggah sebagai jawaban pertanyaan file,
+// menggantikan jawaban sebelumnya untuk pertanyaan yang sama. Jawaban hanya dapat diubah selama
+// formulir masih draft.
+func (uc *applicationUsecase) AttachFileAnswer(application *entity.Internship_ApplicationForm, question *entity.ApplicationQuestion, documentID uint) error {
+	if application.Status != constants.StatusDraft {
+		return ErrApplicationClosed
+	}
+	answer := entity.ApplicationAnswer{
+		Key:        question.Key,
+		Label:      question.Label,
+		Type:       question.Type,
+		DocumentID: documentID,
+	}
+	if err := uc.applications.SetAnswer(application.ID, answer); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrApplicationNotFound
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"miniproject/constants"
+	"miniproject/entity"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyValidatesAnswers(t *testing.T) {
+	repos, uc, listing := newTestUsecase(t, 2)
+	listing.Questions = []entity.ApplicationQuestion{
+		{Key: "division", Label: "Divisi", Type: constants.QuestionChoice, Required: true, Options: []string{"Backend", "Frontend"}},
+		{Key: "portfolio", Label: "Portofolio", Type: constants.QuestionFile},
+	}
+	assert.NoError(t, repos.Listings.Save(listing))
+
+	form := validForm(listing.ID)
+	form.Answers = []entity.ApplicationAnswer{{Key: "division", Text: "Mobile"}}
+	_, err := uc.Apply(applicant(1), form)
+	var validationErr *ValidationError
+	if assert.ErrorAs(t, err, &validationErr) {
+		assert.Contains(t, validationErr.Fields, "answers.division")
+	}
+
+	form.Answers = []entity.ApplicationAnswer{{Key: "division", Text: "Backend", Label: "diabaikan"}}
+	application, err := uc.Apply(applicant(1), form)
+	if assert.NoError(t, err) {
+		stored, err := repos.Applications.FindByID(application.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, []entity.ApplicationAnswer{
+			{Key: "division", Label: "Divisi", Type: constants.QuestionChoice, Text: "Backend"},
+		}, stored.Answers)
+	}
+}
+
+func TestApplyRejectsRequiredFileQuestion(t *testing.T) {
+	repos, uc, listing := newTestUsecase(t, 2)
+	listing.Questions = []entity.ApplicationQuestion{
+		{Key: "portfolio", Label: "Portofolio", Type: constants.QuestionFile, Required: true},
+	}
+	assert.NoError(t, repos.Listings.Save(listing))
+
+	_, err := uc.Apply(applicant(1), validForm(listing.ID))
+	var validationErr *ValidationError
+	if assert.ErrorAs(t, err, &validationErr) {
+		assert.Equal(t, []string{"answers.portfolio"}, sortedKeys(validationErr.Fields))
+	}
+	stored, err := repos.Listings.FindByID(listing.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, stored.Quota)
+}
+
+func TestAttachFileAnswer(t *testing.T) {
+	repos, uc, listing := newTestUsecase(t, 2)
+	listing.Questions = []entity.ApplicationQuestion{
+		{Key: "motivation", Label: "Motivasi", Type: constants.QuestionText},
+		{Key: "portfolio", Label: "Portofolio", Type: constants.QuestionFile},
+	}
+	assert.NoError(t, repos.Listings.Save(listing))
+	application, err := uc.CreateDraft(applicant(1), listing.ID, DraftChanges{})
+	assert.NoError(t, err)
+
+	_, err = uc.GetFileQuestion(application, "motivation")
+	assert.ErrorIs(t, err, ErrQuestionNotFound)
+
+	question, err := uc.GetFileQuestion(application, "portfolio")
+	if assert.NoError(t, err) {
+		assert.NoError(t, uc.AttachFileAnswer(application, question, 7))
+		assert.NoError(t, uc.AttachFileAnswer(application, question, 9))
+	}
+	stored, err := repos.Applications.FindByID(application.ID)
+	if assert.NoError(t, err) && assert.Len(t, stored.Answers, 1) {
+		assert.Equal(t, uint(9), stored.Answers[0].DocumentID)
+	}
+
+	stored.Nim, stored.GPA, stored.EducationLevel = "123456", 3.5, "S1"
+	submitted, err := uc.SubmitDraft(stored)
+	if assert.NoError(t, err) {
+		assert.ErrorIs(t, uc.AttachFileAnswer(submitted, question, 10), ErrApplicationClosed)
+	}
+}
@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"errors"
+	"miniproject/applications/questions"
+	"miniproject/constants"
+	"miniproject/entity"
+	liststatus "miniproject/listings/status"
+	"miniproject/repository"
+)
+
+var ErrNotDraft = errors.New("Formulir sudah diajukan sehingga tidak dapat diubah sebagai draft")
+
+// DraftChanges berisi isi draft yang dikirim pengguna. Field nil tidak diubah, sedangkan jawaban
+// dengan teks kosong dan tanpa angka menghapus jawaban pertanyaan tersebut.
+type DraftChanges struct {
+	Nim            *string
+	GPA            *float64
+	EducationLevel *string
+	Answers        []entity.ApplicationAnswer
+}
+
+// CreateDraft menyimpan draft formulir untuk lowongan listingID tanpa memakai kuota. Isi
+// draft boleh belum lengkap, tetapi field yang dikirim tetap divalidasi.
+func (uc *applicationUsecase) CreateDraft(applicant *entity.User, listingID uint, changes DraftChanges) (*entity.Internship_ApplicationForm, error) {
+	listing, err := uc.findOpenListing(listingID)
+	if err != nil {
+		return nil, err
+	}
+
+	application := entity.Internship_ApplicationForm{
+		Major:               applicant.Major,
+		Status:              constants.StatusDraft,
+		UserID:              applicant.ID,
+		UserEmail:           applicant.Email,
+		Username:            applicant.Username,
+		SelectedTitle:       listing.Title,
+		InternshipListingID: listing.ID,
+	}
+	if err := applyDraftChanges(&application, listing, changes); err != nil {
+		return nil, err
+	}
+	if err := uc.applications.CreateDraft(&application); err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			return nil, ErrAlreadyApplied
+		}
+		return nil, err
+	}
+	return &application, nil
+}
+
+// UpdateDraft memperbarui field draft yang dikirim. Formulir yang sudah diajukan tidak dapat diubah.
+func (uc *applicationUsecase) UpdateDraft(application *entity.Internship_ApplicationForm, changes DraftChanges) (*entity.Internship_ApplicationForm, error) {
+	if application.Status != constants.StatusDraft {
+		return nil, ErrNotDraft
+	}
+	listing, err := uc.findListing(application.InternshipListingID)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := *application
+	if err := applyDraftChanges(&updated, listing, changes); err != nil {
+		return nil, err
+	}
+	if err := uc.applications.UpdateDraft(&updated); err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			return nil, ErrNotDraft
+		}
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// SubmitDraft memvalidasi draft secara lengkap, termasuk berkas untuk pertanyaan file yang wajib,
+// lalu mengajukannya menjadi pending. Kuota baru dipakai saat pengajuan ini dan hanya selama
+// lowongan masih menerima pendaftaran.
+func (uc *applicationUsecase) SubmitDraft(application *entity.Internship_ApplicationForm) (*entity.Internship_ApplicationForm, error) {
+	if application.Status != constants.StatusDraft {
+		return nil, ErrNotDraft
+	}
+	listing, err := uc.findListing(application.InternshipListingID)
+	if err != nil {
+		return nil, err
+	}
+	if !liststatus.AcceptsApplications(listing, uc.now()) {
+		return nil, ErrListingClosed
+	}
+
+	inline, files := splitAnswers(listing.Questions, application.Answers)
+	answers, invalidData := questions.Check(listing.Questions, inline)
+	for field, message := range formErrors(application) {
+		invalidData[field] = message
+	}
+	for _, question := range listing.Questions {
+		if question.Type == constants.QuestionFile && question.Required && !hasAnswer(files, question.Key) {
+			invalidData["answers."+question.Key] = "Berkas jawaban wajib diunggah"
+		}
+	}
+	if len(invalidData) > 0 {
+		return nil, &ValidationError{Fields: invalidData}
+	}
+	if listing.Quota <= 0 {
+		return nil, ErrQuotaFull
+	}
+
+	submitted := *application
+	submitted.Answers = append(answers, files...)
+	if err := uc.applications.SubmitDraft(&submitted, maxActiveApplications()); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrConflict):
+			return nil, ErrNotDraft
+		case errors.Is(err, repository.ErrLimitExceeded):
+			return nil, ErrTooManyActive
+		case errors.Is(err, repository.ErrQuotaFull):
+			return nil, ErrQuotaFull
+		case errors.Is(err, repository.ErrNotFound):
+			return nil, ErrListingNotFound
+		}
+		return nil, err
+	}
+	return &submitted, nil
+}
+
+// applyDraftChanges menerapkan changes ke application. Field tetap yang dikirim diperiksa dengan
+// aturan yang sama seperti saat pengajuan, sedangkan jawaban hanya diperiksa formatnya.
+func applyDraftChanges(application *entity.Internship_ApplicationForm, listing *entity.Internship_Listing, changes DraftChanges) error {
+	provided := make(map[string]bool)
+	if changes.Nim != nil {
+		application.Nim = *changes.Nim
+		provided["nim"] = true
+	}
+	if changes.GPA != nil {
+		application.GPA = *changes.GPA
+		provided["gpa"] = true
+	}
+	if changes.EducationLevel != nil {
+		application.EducationLevel = *changes.EducationLevel
+		provided["education_level"] = true
+	}
+
+	answers, invalidData := questions.CheckDraft(listing.Questions, changes.Answers)
+	for field, message := range formErrors(application) {
+		if provided[field] {
+			invalidData[field] = message
+		}
+	}
+	if len(invalidData) > 0 {
+		return &ValidationError{Fields: invalidData}
+	}
+
+	changed := make(map[string]bool, len(changes.Answers))
+	for _, answer := range changes.Answers {
+		changed[answer.Key] = true
+	}
+	merged := make([]entity.ApplicationAnswer, 0, len(application.Answers)+len(answers))
+	for _, answer := range application.Answers {
+		if !changed[answer.Key] {
+			merged = append(merged, answer)
+		}
+	}
+	application.Answers = append(merged, answers...)
+	return nil
+}
+
+// splitAnswers memisahkan jawaban teks, pilihan, dan angka dari jawaban berkas. Jawaban untuk
+// pertanyaan yang sudah dihapus dari lowongan diabaikan.
+func splitAnswers(schema []entity.ApplicationQuestion, answers []entity.ApplicationAnswer) (inline, files []entity.ApplicationAnswer) {
+	for _, answer := range answers {
+		question, ok := questions.Find(schema, answer.Key)
+		switch {
+		case !ok:
+			continue
+		case question.Type == constants.QuestionFile:
+			if answer.DocumentID != 0 {
+				files = append(files, answer)
+			}
+		default:
+			inline = append(inline, answer)
+		}
+	}
+	return inline, files
+}
+
+func hasAnswer(answers []entity.ApplicationAnswer, key string) bool {
+	for _, answer := range answers {
+		if answer.Key == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"miniproject/constants"
+	"miniproject/entity"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func text(value string) *string {
+	return &value
+}
+
+func sortedKeys(fields map[string]string) []string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestDraftDoesNotConsumeQuota(t *testing.T) {
+	repos, uc, listing := newTestUsecase(t, 1)
+
+	draft, err := uc.CreateDraft(applicant(1), listing.ID, DraftChanges{Nim: text("123456")})
+	if assert.NoError(t, err) {
+		assert.Equal(t, constants.StatusDraft, draft.Status)
+	}
+	stored, err := repos.Listings.FindByID(listing.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, stored.Quota)
+
+	// Draft tidak termasuk daftar kandidat admin
+	applications, err := uc.GetApplications()
+	assert.NoError(t, err)
+	assert.Empty(t, applications)
+
+	// Satu pengguna tetap hanya boleh memiliki satu formulir per lowongan
+	_, err = uc.Apply(applicant(1), validForm(listing.ID))
+	assert.ErrorIs(t, err, ErrAlreadyApplied)
+
+	// Membuang draft tidak mengembalikan kuota yang memang belum dipakai
+	assert.NoError(t, uc.Cancel(draft, userActor))
+	stored, err = repos.Listings.FindByID(listing.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, stored.Quota)
+}
+
+func TestUpdateDraftValidatesFields(t *testing.T) {
+	repos, uc, listing := newTestUsecase(t, 1)
+	listing.Questions = []entity.ApplicationQuestion{
+		{Key: "division", Label: "Divisi", Type: constants.QuestionChoice, Required: true, Options: []string{"Backend", "Frontend"}},
+		{Key: "motivation", Label: "Motivasi", Type: constants.QuestionText},
+	}
+	assert.NoError(t, repos.Listings.Save(listing))
+	draft, err := uc.CreateDraft(applicant(1), listing.ID, DraftChanges{
+		Answers: []entity.ApplicationAnswer{{Key: "motivation", Text: "Belajar"}},
+	})
+	assert.NoError(t, err)
+
+	gpa := 0.0
+	_, err = uc.UpdateDraft(draft, DraftChanges{
+		GPA:     &gpa,
+		Answers: []entity.ApplicationAnswer{{Key: "division", Text: "Mobile"}},
+	})
+	var validationErr *ValidationError
+	if assert.ErrorAs(t, err, &validationErr) {
+		assert.Equal(t, []string{"answers.division", "gpa"}, sortedKeys(validationErr.Fields))
+	}
+
+	gpa = 3.7
+	updated, err := uc.UpdateDraft(draft, DraftChanges{
+		GPA:     &gpa,
+		Answers: []entity.ApplicationAnswer{{Key: "division", Text: "Backend"}, {Key: "motivation"}},
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, 3.7, updated.GPA)
+		assert.Equal(t, []entity.ApplicationAnswer{
+			{Key: "division", Label: "Divisi", Type: constants.QuestionChoice, Text: "Backend"},
+		}, updated.Answers)
+	}
+	stored, err := repos.Applications.FindByID(draft.ID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 3.7, stored.GPA)
+	}
+}
+
+func TestSubmitDraft(t *testing.T) {
+	repos, uc, listing := newTestUsecase(t, 1)
+	listing.Questions = []entity.ApplicationQuestion{
+		{Key: "portfolio", Label: "Portofolio", Type: constants.QuestionFile, Required: true},
+	}
+	assert.NoError(t, repos.Listings.Save(listing))
+	draft, err := uc.CreateDraft(applicant(1), listing.ID, DraftChanges{Nim: text("123456")})
+	assert.NoError(t, err)
+
+	_, err = uc.SubmitDraft(draft)
+	var validationErr *ValidationError
+	if assert.ErrorAs(t, err, &validationErr) {
+		assert.Equal(t, []string{"answers.portfolio", "education_level", "gpa"}, sortedKeys(validationErr.Fields))
+	}
+
+	gpa := 3.8
+	draft, err = uc.UpdateDraft(draft, DraftChanges{GPA: &gpa, EducationLevel: text("S1")})
+	assert.NoError(t, err)
+	question, err := uc.GetFileQuestion(draft, "portfolio")
+	assert.NoError(t, err)
+	assert.NoError(t, uc.AttachFileAnswer(draft, question, 5))
+	draft, err = uc.GetApplication(draft.ID)
+	assert.NoError(t, err)
+
+	submitted, err := uc.SubmitDraft(draft)
+	if assert.NoError(t, err) {
+		assert.Equal(t, constants.StatusPending, submitted.Status)
+	}
+	stored, err := repos.Listings.FindByID(listing.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, stored.Quota)
+
+	history, err := uc.GetStatusHistory(draft.ID)
+	if assert.NoError(t, err) && assert.Len(t, history, 1) {
+		assert.Equal(t, constants.StatusDraft, history[0].FromStatus)
+		assert.Equal(t, constants.StatusPending, history[0].ToStatus)
+	}
+
+	_, err = uc.SubmitDraft(submitted)
+	assert.ErrorIs(t, err, ErrNotDraft)
+	_, err = uc.UpdateDraft(submitted, DraftChanges{Nim: text("654321")})
+	assert.ErrorIs(t, err, ErrNotDraft)
+}
+
+func TestSubmitDraftAfterDeadline(t *testing.T) {
+	repos, uc, listing := newTestUsecase(t, 1)
+	deadline := time.Now().Add(time.Hour)
+	listing.ApplicationDeadline = &deadline
+	assert.NoError(t, repos.Listings.Save(listing))
+
+	gpa := 3.8
+	draft, err := uc.CreateDraft(applicant(1), listing.ID, DraftChanges{Nim: text("123456"), GPA: &gpa, EducationLevel: text("S1")})
+	assert.NoError(t, err)
+
+	uc.(*applicationUsecase).now = func() time.Time { return deadline.Add(time.Minute) }
+	_, err = uc.SubmitDraft(draft)
+	assert.ErrorIs(t, err, ErrListingClosed)
+}
@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"miniproject/constants"
+	"miniproject/entity"
+	"miniproject/repository"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultOfferValidHours adalah masa berlaku offer jika OFFERVALIDHOURS tidak diatur.
+	DefaultOfferValidHours = 48
+	// DefaultOfferExpiryMinutes adalah jeda pemeriksaan offer kedaluwarsa jika OFFEREXPIRYMINUTES
+	// tidak diatur.
+	DefaultOfferExpiryMinutes = 15
+)
+
+var (
+	ErrOfferNotFound   = errors.New("Tawaran magang tidak ditemukan")
+	ErrOfferNotPending = errors.New("Tawaran magang sudah dijawab, kedaluwarsa, atau ditarik")
+	ErrOfferExpired    = errors.New("Batas waktu menjawab tawaran magang sudah lewat")
+)
+
+// systemActor dicatat pada riwayat status untuk perubahan yang dilakukan otomatis oleh aplikasi.
+var systemActor = Actor{Type: constants.SubjectSystem}
+
+// offerValidity membaca masa berlaku offer dari OFFERVALIDHOURS.
+func offerValidity() time.Duration {
+	hours, err := strconv.Atoi(os.Getenv("OFFERVALIDHOURS"))
+	if err != nil || hours <= 0 {
+		hours = DefaultOfferValidHours
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// newOffer membuat offer untuk kandidat yang baru diterima; repository melengkapi data formulirnya.
+func (uc *applicationUsecase) newOffer() *entity.Offer {
+	return &entity.Offer{ExpiresAt: uc.now().Add(offerValidity())}
+}
+
+func (uc *applicationUsecase) GetOffer(id uint) (*entity.Offer, error) {
+	offer, err := uc.offers.FindByID(id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrOfferNotFound
+	}
+	return offer, err
+}
+
+func (uc *applicationUsecase) GetUserOffers(userID uint) ([]entity.Offer, error) {
+	return uc.offers.FindByUserID(userID)
+}
+
+func (uc *applicationUsecase) GetListingOffers(listingID uint) ([]entity.Offer, error) {
+	if _, err := uc.listings.FindByID(listingID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrListingNotFound
+		}
+		return nil, err
+	}
+	return uc.offers.FindByListingID(listingID)
+}
+
+// AcceptOffer mengonfirmasi tempat magang kandidat sebelum offer kedaluwarsa.
+func (uc *applicationUsecase) AcceptOffer(offer *entity.Offer) error {
+	if offer.Status != constants.OfferPending {
+		return ErrOfferNotPending
+	}
+	now := uc.now()
+	if offer.ExpiresAt.Before(now) {
+		return ErrOfferExpired
+	}
+	return uc.respond(offer, constants.OfferAccepted, now, nil)
+}
+
+// DeclineOffer menolak offer, membatalkan formulir kandidat, dan memberikan kursinya kepada
+// kandidat daftar tunggu berikutnya.
+func (uc *applicationUsecase) DeclineOffer(offer *entity.Offer, actor Actor, reason string) error {
+	if offer.Status != constants.OfferPending {
+		return ErrOfferNotPending
+	}
+	if reason == "" {
+		reason = "Kandidat menolak tawaran magang"
+	}
+	return uc.release(offer, constants.OfferDeclined, actor, reason)
+}
+
+// ExpireOffers menandai offer yang tidak dijawab sampai batas waktunya sebagai kedaluwarsa,
+// membatalkan formulirnya, lalu kursinya berpindah ke kandidat daftar tunggu berikutnya.
+// Mengembalikan jumlah offer yang kedaluwarsa.
+func (uc *applicationUsecase) ExpireOffers() (int, error) {
+	expired, err := uc.offers.FindExpired(uc.now())
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for i := range expired {
+		err := uc.release(&expired[i], constants.OfferExpired, systemActor, "Batas waktu menjawab tawaran magang terlewati")
+		if errors.Is(err, ErrOfferNotPending) || errors.Is(err, ErrStatusChanged) {
+			// Kandidat sudah menjawab atau formulirnya diubah sejak offer dibaca
+			continue
+		}
+		if err != nil {
+			return count, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+// release menutup offer dengan status dan membatalkan formulirnya dalam satu transaksi sehingga
+// kursi kandidat dilepas.
+func (uc *applicationUsecase) release(offer *entity.Offer, offerStatus string, actor Actor, reason string) error {
+	application, err := uc.GetApplication(offer.InternshipApplicationFormID)
+	if err != nil {
+		return err
+	}
+	if application.Status != constants.StatusAccepted {
+		return ErrStatusChanged
+	}
+
+	change := repository.StatusChange{
+		Application: application,
+		History: &entity.ApplicationStatusHistory{
+			FromStatus:    application.Status,
+			ToStatus:      constants.StatusCanceled,
+			ChangedByType: actor.Type,
+			ChangedByID:   actor.ID,
+			Reason:        reason,
+		},
+	}
+	if err := uc.respond(offer, offerStatus, uc.now(), &change); err != nil {
+		return err
+	}
+	uc.promoteNext(application.InternshipListingID)
+	return nil
+}
+
+func (uc *applicationUsecase) respond(offer *entity.Offer, offerStatus string, now time.Time, change *repository.StatusChange) error {
+	err := uc.offers.Respond(offer, offerStatus, now, change)
+	switch {
+	case errors.Is(err, repository.ErrConflict):
+		// Offer atau formulirnya sudah diubah proses lain sejak dibaca
+		return ErrOfferNotPending
+	case errors.Is(err, repository.ErrNotFound):
+		return ErrApplicationNotFound
+	}
+	return err
+}
+
+// OfferExpiryInterval membaca jeda pemeriksaan offer kedaluwarsa (menit) dari OFFEREXPIRYMINUTES.
+func OfferExpiryInterval() time.Duration {
+	minutes, err := strconv.Atoi(os.Getenv("OFFEREXPIRYMINUTES"))
+	if err != nil || minutes <= 0 {
+		minutes = DefaultOfferExpiryMinutes
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// RunOfferExpiry menjalankan ExpireOffers setiap interval sampai ctx dibatalkan.
+func RunOfferExpiry(ctx context.Context, uc ApplicationUsecase, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := uc.ExpireOffers(); err != nil {
+				log.Println("gagal memproses tawaran magang yang kedaluwarsa:", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"context"
+	"miniproject/constants"
+	"miniproject/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransitionToAcceptedCreatesOffer(t *testing.T) {
+	repos, uc, listing := newTestUsecase(t, 10)
+	listing.Seats = 1
+	assert.NoError(t, repos.Listings.Save(listing))
+	application := &entity.Internship_ApplicationForm{UserID: 7, Status: constants.StatusVerified, InternshipListingID: listing.ID}
+	assert.NoError(t, repos.Applications.Create(application))
+	other := &entity.Internship_ApplicationForm{UserID: 8, Status: constants.StatusVerified, InternshipListingID: listing.ID}
+	assert.NoError(t, repos.Applications.Create(other))
+
+	_, err := uc.Transition(application.ID, constants.StatusAccepted, adminActor, "Lolos wawancara")
+	assert.NoError(t, err)
+	// Kursi satu-satunya sudah terisi sehingga kandidat lain tidak dapat diterima
+	_, err = uc.Transition(other.ID, constants.StatusAccepted, adminActor, "Lolos wawancara")
+	assert.ErrorIs(t, err, ErrSeatsFull)
+
+	offers, err := uc.GetListingOffers(listing.ID)
+	if assert.NoError(t, err) && assert.Len(t, offers, 1) {
+		assert.Equal(t, application.ID, offers[0].InternshipApplicationFormID)
+		assert.Equal(t, uint(7), offers[0].UserID)
+		assert.Equal(t, constants.OfferPending, offers[0].Status)
+	}
+
+	_, err = uc.GetListingOffers(999)
+	assert.ErrorIs(t, err, ErrListingNotFound)
+}
+
+func TestAcceptOffer(t *testing.T) {
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	_, uc, applications, _ := newWaitlistUsecase(t, now)
+	assert.NoError(t, uc.Cancel(applications[0], userActor))
+	offers, _ := uc.GetUserOffers(3)
+	offer := &offers[0]
+
+	assert.NoError(t, uc.AcceptOffer(offer))
+	assert.Equal(t, constants.OfferAccepted, offer.Status)
+	assert.NotNil(t, offer.RespondedAt)
+	assert.ErrorIs(t, uc.AcceptOffer(offer), ErrOfferNotPending)
+
+	stored, err := uc.GetApplication(applications[2].ID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, constants.StatusAccepted, stored.Status)
+	}
+}
+
+func TestAcceptExpiredOffer(t *testing.T) {
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	_, uc, applications, _ := newWaitlistUsecase(t, now)
+	assert.NoError(t, uc.Cancel(applications[0], userActor))
+	offers, _ := uc.GetUserOffers(3)
+
+	uc.now = func() time.Time { return now.Add(DefaultOfferValidHours*time.Hour + time.Minute) }
+	assert.ErrorIs(t, uc.AcceptOffer(&offers[0]), ErrOfferExpired)
+}
+
+func TestDeclineOfferPromotesNextWaitlisted(t *testing.T) {
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	repos, uc, applications, notified := newWaitlistUsecase(t, now)
+	assert.NoError(t, uc.Cancel(applications[0], userActor))
+	offers, _ := uc.GetUserOffers(3)
+	offer := &offers[0]
+
+	assert.NoError(t, uc.DeclineOffer(offer, Actor{Type: constants.SubjectUser, ID: 3}, ""))
+	assert.Equal(t, constants.OfferDeclined, offer.Status)
+	assert.ErrorIs(t, uc.DeclineOffer(offer, userActor, ""), ErrOfferNotPending)
+
+	assert.Equal(t, []string{
+		constants.StatusCanceled,
+		constants.StatusAccepted,
+		constants.StatusCanceled,
+	}, statuses(t, repos, applications))
+	assert.Equal(t, []uint{applications[2].ID, applications[1].ID}, *notified)
+
+	history, err := uc.GetStatusHistory(applications[2].ID)
+	if assert.NoError(t, err) && assert.Len(t, history, 2) {
+		assert.Equal(t, "Kandidat menolak tawaran magang", history[1].Reason)
+	}
+}
+
+func TestExpireOffersMovesSeatToNextWaitlisted(t *testing.T) {
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	repos, uc, applications, notified := newWaitlistUsecase(t, now)
+	assert.NoError(t, uc.Cancel(applications[0], userActor))
+
+	count, err := uc.ExpireOffers()
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	uc.now = func() time.Time { return now.Add(DefaultOfferValidHours*time.Hour + time.Minute) }
+	count, err = uc.ExpireOffers()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	assert.Equal(t, []string{
+		constants.StatusCanceled,
+		constants.StatusAccepted,
+		constants.StatusCanceled,
+	}, statuses(t, repos, applications))
+	assert.Equal(t, []uint{applications[2].ID, applications[1].ID}, *notified)
+
+	expired, err := uc.GetUserOffers(3)
+	if assert.NoError(t, err) && assert.Len(t, expired, 1) {
+		assert.Equal(t, constants.OfferExpired, expired[0].Status)
+	}
+}
+
+func TestCancelAcceptedWithdrawsPendingOffer(t *testing.T) {
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	_, uc, applications, _ := newWaitlistUsecase(t, now)
+	assert.NoError(t, uc.Cancel(applications[0], userActor))
+
+	promoted, err := uc.GetApplication(applications[2].ID)
+	assert.NoError(t, err)
+	assert.NoError(t, uc.Cancel(promoted, Actor{Type: constants.SubjectUser, ID: 3}))
+
+	offers, err := uc.GetUserOffers(3)
+	if assert.NoError(t, err) && assert.Len(t, offers, 1) {
+		assert.Equal(t, constants.OfferWithdrawn, offers[0].Status)
+	}
+}
+
+func TestOfferExpiryInterval(t *testing.T) {
+	t.Setenv("OFFEREXPIRYMINUTES", "")
+	assert.Equal(t, DefaultOfferExpiryMinutes*time.Minute, OfferExpiryInterval())
+
+	t.Setenv("OFFEREXPIRYMINUTES", "5")
+	assert.Equal(t, 5*time.Minute, OfferExpiryInterval())
+}
+
+func TestRunOfferExpiryStopsOnCancel(t *testing.T) {
+	_, uc, _ := newTestUsecase(t, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		RunOfferExpiry(ctx, uc, time.Millisecond)
+		close(done)
+	}()
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("RunOfferExpiry tidak berhenti setelah context dibatalkan")
+	}
+}
@@ -0,0 +1,186 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"miniproject/applications/ranking"
+	"miniproject/applications/screening"
+	"miniproject/constants"
+	"miniproject/entity"
+	"miniproject/repository"
+)
+
+var (
+	ErrNoSeats             = errors.New("Jumlah kursi lowongan belum diatur")
+	ErrInvalidWaitlistSize = errors.New("Ukuran daftar tunggu tidak boleh negatif")
+)
+
+// SelectionOptions mengatur satu selection run.
+type SelectionOptions struct {
+	// Weights nil memakai bobot lowongan atau ranking.DefaultWeights.
+	Weights *entity.RankingWeights
+	// WaitlistSize nil memakai jumlah kursi lowongan.
+	WaitlistSize *int
+	// DryRun hanya menghitung hasil tanpa menyimpan perubahan status.
+	DryRun bool
+}
+
+// SelectionOutcome adalah keputusan selection run untuk satu formulir.
+type SelectionOutcome struct {
+	ApplicationID uint    `json:"application_id"`
+	Username      string  `json:"username"`
+	Rank          int     `json:"rank"`
+	Score         float64 `json:"score"`
+	FromStatus    string  `json:"from_status"`
+	Status        string  `json:"status"`
+	Reason        string  `json:"reason"`
+}
+
+// SelectionRun adalah ringkasan hasil selection run satu lowongan. Jumlah per status dihitung
+// dari status akhir setiap formulir; Skipped adalah formulir yang belum diverifikasi.
+type SelectionRun struct {
+	ListingID  uint               `json:"listing_id"`
+	Seats      int                `json:"seats"`
+	DryRun     bool               `json:"dry_run"`
+	Accepted   int                `json:"accepted"`
+	Waitlisted int                `json:"waitlisted"`
+	Rejected   int                `json:"rejected"`
+	Skipped    int                `json:"skipped"`
+	Outcomes   []SelectionOutcome `json:"outcomes"`
+}
+
+func (run *SelectionRun) add(application *entity.Internship_ApplicationForm, to, reason string) {
+	run.Outcomes = append(run.Outcomes, SelectionOutcome{
+		ApplicationID: application.ID,
+		Username:      application.Username,
+		Rank:          application.SelectionRank,
+		Score:         application.SelectionScore,
+		FromStatus:    application.Status,
+		Status:        to,
+		Reason:        reason,
+	})
+	switch to {
+	case constants.StatusAccepted:
+		run.Accepted++
+	case constants.StatusWaitlisted:
+		run.Waitlisted++
+	case constants.StatusRejected:
+		run.Rejected++
+	default:
+		run.Skipped++
+	}
+}
+
+// RunSelection menyaring dan memberi peringkat semua formulir yang belum dibatalkan pada satu
+// lowongan, menerima kandidat teratas sampai kursi penuh, memasukkan kandidat berikutnya ke
+// daftar tunggu, dan menolak sisanya dalam satu transaksi. Formulir yang belum diverifikasi
+// dilewati dan kandidat yang sudah diterima tetap menempati kursinya.
+func (uc *applicationUsecase) RunSelection(listingID uint, options SelectionOptions, actor Actor) (*SelectionRun, error) {
+	listing, err := uc.listings.FindByID(listingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrListingNotFound
+		}
+		return nil, err
+	}
+	if listing.Seats <= 0 {
+		return nil, ErrNoSeats
+	}
+
+	weights := options.Weights
+	if weights == nil {
+		weights = listing.RankingWeights
+	}
+	waitlistSize := listing.Seats
+	if options.WaitlistSize != nil {
+		waitlistSize = *options.WaitlistSize
+	}
+	if waitlistSize < 0 {
+		return nil, ErrInvalidWaitlistSize
+	}
+
+	applications, err := uc.applications.FindByListingID(listing.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &SelectionRun{ListingID: listing.ID, Seats: listing.Seats, DryRun: options.DryRun}
+	var changes []repository.StatusChange
+	decide := func(application *entity.Internship_ApplicationForm, to, reason string) {
+		run.add(application, to, reason)
+		change := repository.StatusChange{
+			Application: application,
+			History: &entity.ApplicationStatusHistory{
+				FromStatus:    application.Status,
+				ToStatus:      to,
+				ChangedByType: actor.Type,
+				ChangedByID:   actor.ID,
+				Reason:        reason,
+			},
+		}
+		if to == constants.StatusAccepted {
+			change.Offer = uc.newOffer()
+		}
+		changes = append(changes, change)
+	}
+
+	openSeats := listing.Seats
+	var eligible, screenedOut, skipped []*entity.Internship_ApplicationForm
+	results := make(map[uint]screening.Result)
+	for i := range applications {
+		application := &applications[i]
+		switch application.Status {
+		case constants.StatusAccepted:
+			openSeats--
+			run.add(application, application.Status, "Sudah diterima sebelumnya")
+		case constants.StatusVerified, constants.StatusWaitlisted:
+			result := screening.Evaluate(listing.ScreeningCriteria, application)
+			results[application.ID] = result
+			if result.Passed {
+				eligible = append(eligible, application)
+			} else {
+				screenedOut = append(screenedOut, application)
+			}
+		case constants.StatusPending:
+			skipped = append(skipped, application)
+		}
+	}
+	if openSeats < 0 {
+		openSeats = 0
+	}
+
+	for _, candidate := range ranking.Rank(weights, eligible) {
+		application := candidate.Application
+		application.SelectionRank, application.SelectionScore = candidate.Rank, candidate.Score
+		switch {
+		case candidate.Rank <= openSeats:
+			decide(application, constants.StatusAccepted,
+				fmt.Sprintf("Peringkat %d dari %d kandidat, masuk %d kursi tersisa", candidate.Rank, len(eligible), openSeats))
+		case candidate.Rank <= openSeats+waitlistSize:
+			decide(application, constants.StatusWaitlisted,
+				fmt.Sprintf("Peringkat %d dari %d kandidat, masuk daftar tunggu", candidate.Rank, len(eligible)))
+		default:
+			decide(application, constants.StatusRejected,
+				fmt.Sprintf("Peringkat %d dari %d kandidat, di luar kursi dan daftar tunggu", candidate.Rank, len(eligible)))
+		}
+	}
+	for _, application := range screenedOut {
+		application.SelectionRank, application.SelectionScore = 0, 0
+		decide(application, constants.StatusRejected, results[application.ID].FailureSummary())
+	}
+	for _, application := range skipped {
+		run.add(application, application.Status, "Formulir belum diverifikasi")
+	}
+
+	if options.DryRun || len(changes) == 0 {
+		return run, nil
+	}
+	if err := uc.applications.TransitionMany(changes); err != nil {
+		// Kursi yang penuh berarti kandidat lain diterima sejak formulir dibaca
+		if errors.Is(err, repository.ErrConflict) || errors.Is(err, repository.ErrNotFound) || errors.Is(err, repository.ErrSeatsFull) {
+			return nil, ErrStatusChanged
+		}
+		return nil, err
+	}
+	return run, nil
+}
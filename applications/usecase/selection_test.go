@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"miniproject/constants"
+	"miniproject/entity"
+	"miniproject/repository"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// seedVerified membuat formulir terverifikasi dengan IPK tertentu pada lowongan.
+func seedVerified(t *testing.T, repos *repository.Repositories, listing *entity.Internship_Listing, gpas ...float64) []*entity.Internship_ApplicationForm {
+	var applications []*entity.Internship_ApplicationForm
+	for _, gpa := range gpas {
+		application := &entity.Internship_ApplicationForm{GPA: gpa, Status: constants.StatusVerified, InternshipListingID: listing.ID}
+		assert.NoError(t, repos.Applications.Create(application))
+		applications = append(applications, application)
+	}
+	return applications
+}
+
+func statuses(t *testing.T, repos *repository.Repositories, applications []*entity.Internship_ApplicationForm) []string {
+	var result []string
+	for _, application := range applications {
+		stored, err := repos.Applications.FindByID(application.ID)
+		assert.NoError(t, err)
+		result = append(result, stored.Status)
+	}
+	return result
+}
+
+func TestRunSelection(t *testing.T) {
+	repos, uc, listing := newTestUsecase(t, 10)
+	listing.Seats = 2
+	listing.ScreeningCriteria = &entity.ScreeningCriteria{MinGPA: 3.0}
+	assert.NoError(t, repos.Listings.Save(listing))
+	applications := seedVerified(t, repos, listing, 3.4, 3.9, 2.8, 3.6, 3.1)
+	pending := &entity.Internship_ApplicationForm{GPA: 4.0, Status: constants.StatusPending, InternshipListingID: listing.ID}
+	assert.NoError(t, repos.Applications.Create(pending))
+
+	waitlistSize := 1
+	run, err := uc.RunSelection(listing.ID, SelectionOptions{WaitlistSize: &waitlistSize}, adminActor)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 2, run.Accepted)
+		assert.Equal(t, 1, run.Waitlisted)
+		assert.Equal(t, 2, run.Rejected)
+		assert.Equal(t, 1, run.Skipped)
+	}
+
+	assert.Equal(t, []string{
+		constants.StatusWaitlisted,
+		constants.StatusAccepted,
+		constants.StatusRejected,
+		constants.StatusAccepted,
+		constants.StatusRejected,
+	}, statuses(t, repos, applications))
+
+	waitlisted, err := repos.Applications.FindByID(applications[0].ID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 3, waitlisted.SelectionRank)
+	}
+
+	// IPK 2.8 ditolak karena kriteria, alasannya dicatat pada riwayat
+	history, err := repos.Applications.FindStatusHistory(applications[2].ID)
+	if assert.NoError(t, err) && assert.Len(t, history, 1) {
+		assert.Contains(t, history[0].Reason, "di bawah minimum")
+	}
+
+	// Setiap kandidat yang diterima mendapat offer
+	offers, err := repos.Offers.FindByListingID(listing.ID)
+	if assert.NoError(t, err) && assert.Len(t, offers, 2) {
+		assert.Equal(t, applications[1].ID, offers[0].InternshipApplicationFormID)
+		assert.Equal(t, applications[3].ID, offers[1].InternshipApplicationFormID)
+	}
+}
+
+func TestRunSelectionDryRun(t *testing.T) {
+	repos, uc, listing := newTestUsecase(t, 10)
+	listing.Seats = 1
+	assert.NoError(t, repos.Listings.Save(listing))
+	applications := seedVerified(t, repos, listing, 3.6, 3.8)
+
+	run, err := uc.RunSelection(listing.ID, SelectionOptions{DryRun: true}, adminActor)
+	if assert.NoError(t, err) {
+		assert.True(t, run.DryRun)
+		assert.Equal(t, 1, run.Accepted)
+		assert.Equal(t, 1, run.Waitlisted)
+		assert.Equal(t, applications[1].ID, run.Outcomes[0].ApplicationID)
+	}
+	assert.Equal(t, []string{constants.StatusVerified, constants.StatusVerified}, statuses(t, repos, applications))
+}
+
+func TestRunSelectionKeepsAcceptedSeats(t *testing.T) {
+	repos, uc, listing := newTestUsecase(t, 10)
+	listing.Seats = 2
+	assert.NoError(t, repos.Listings.Save(listing))
+	accepted := &entity.Internship_ApplicationForm{GPA: 3.5, Status: constants.StatusAccepted, InternshipListingID: listing.ID}
+	assert.NoError(t, repos.Applications.Create(accepted))
+	applications := seedVerified(t, repos, listing, 3.9, 3.8)
+
+	zero := 0
+	run, err := uc.RunSelection(listing.ID, SelectionOptions{WaitlistSize: &zero}, adminActor)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 2, run.Accepted)
+		assert.Equal(t, 1, run.Rejected)
+	}
+	assert.Equal(t, []string{constants.StatusAccepted, constants.StatusRejected}, statuses(t, repos, applications))
+}
+
+func TestRunSelectionRequiresSeats(t *testing.T) {
+	_, uc, listing := newTestUsecase(t, 10)
+
+	_, err := uc.RunSelection(listing.ID, SelectionOptions{}, adminActor)
+	assert.ErrorIs(t, err, ErrNoSeats)
+}
@@ -0,0 +1,342 @@
+package usecase
+
+import (
+	"errors"
+	"miniproject/applications/questions"
+	"miniproject/applications/screening"
+	"miniproject/applications/status"
+	"miniproject/constants"
+	"miniproject/entity"
+	liststatus "miniproject/listings/status"
+	"miniproject/repository"
+	"os"
+	"strconv"
+	"time"
+)
+
+// DefaultMaxActiveApplications adalah batas formulir aktif per pengguna jika
+// MAXACTIVEAPPLICATIONS tidak diatur.
+const DefaultMaxActiveApplications = 3
+
+var (
+	ErrListingNotFound     = errors.New("Penawaran magang tidak ditemukan")
+	ErrApplicationNotFound = errors.New("Formulir aplikasi tidak ditemukan")
+	ErrQuotaFull           = errors.New("Kuota pendaftaran magang sudah penuh")
+	ErrListingClosed       = errors.New("Penawaran magang tidak sedang menerima pendaftaran")
+	ErrAlreadyCanceled     = errors.New("Formulir aplikasi sudah dibatalkan sebelumnya")
+	ErrAlreadyApplied      = errors.New("Anda sudah mendaftar pada penawaran magang ini")
+	ErrTooManyActive       = errors.New("Jumlah pendaftaran magang aktif Anda sudah mencapai batas")
+	ErrInvalidTransition   = status.ErrInvalidTransition
+	ErrStatusChanged       = errors.New("Status formulir sudah diubah oleh proses lain, silakan muat ulang")
+	ErrSeatsFull           = errors.New("Semua kursi penawaran magang sudah terisi")
+)
+
+// Actor adalah akun yang melakukan perubahan status formulir dan dicatat pada riwayat status.
+type Actor struct {
+	Type string
+	ID   uint
+}
+
+// ValidationError berisi daftar field formulir yang tidak valid beserta alasannya.
+type ValidationError struct {
+	Fields map[string]string
+}
+
+func (e *ValidationError) Error() string {
+	return "Data formulir tidak valid"
+}
+
+// ApplicationUsecase berisi aturan bisnis pendaftaran magang (kuota, pembatalan, perubahan status,
+// dan penyaringan kandidat) yang dapat dipanggil dari HTTP, CLI, maupun job.
+type ApplicationUsecase interface {
+	Apply(applicant *entity.User, form *entity.Internship_ApplicationForm) (*entity.Internship_ApplicationForm, error)
+	Cancel(application *entity.Internship_ApplicationForm, actor Actor) error
+	Transition(id uint, to string, actor Actor, reason string) (*entity.Internship_ApplicationForm, error)
+	GetApplication(id uint) (*entity.Internship_ApplicationForm, error)
+	GetApplications() ([]entity.Internship_ApplicationForm, error)
+	GetStatusHistory(id uint) ([]entity.ApplicationStatusHistory, error)
+	PreviewScreening(id uint) (*screening.Result, error)
+	Screen(id uint, actor Actor) (*entity.Internship_ApplicationForm, *screening.Result, error)
+	RunSelection(listingID uint, options SelectionOptions, actor Actor) (*SelectionRun, error)
+	GetOffer(id uint) (*entity.Offer, error)
+	GetUserOffers(userID uint) ([]entity.Offer, error)
+	GetListingOffers(listingID uint) ([]entity.Offer, error)
+	AcceptOffer(offer *entity.Offer) error
+	DeclineOffer(offer *entity.Offer, actor Actor, reason string) error
+	ExpireOffers() (int, error)
+	GetFileQuestion(application *entity.Internship_ApplicationForm, key string) (*entity.ApplicationQuestion, error)
+	AttachFileAnswer(application *entity.Internship_ApplicationForm, question *entity.ApplicationQuestion, documentID uint) error
+	CreateDraft(applicant *entity.User, listingID uint, changes DraftChanges) (*entity.Internship_ApplicationForm, error)
+	UpdateDraft(application *entity.Internship_ApplicationForm, changes DraftChanges) (*entity.Internship_ApplicationForm, error)
+	SubmitDraft(application *entity.Internship_ApplicationForm) (*entity.Internship_ApplicationForm, error)
+}
+
+type applicationUsecase struct {
+	applications    repository.ApplicationRepository
+	listings        repository.ListingRepository
+	offers          repository.OfferRepository
+	notifyPromotion PromotionNotifier
+	now             func() time.Time
+}
+
+func NewApplicationUsecase(applications repository.ApplicationRepository, listings repository.ListingRepository, offers repository.OfferRepository) ApplicationUsecase {
+	return &applicationUsecase{
+		applications:    applications,
+		listings:        listings,
+		offers:          offers,
+		notifyPromotion: notifyPromotionByEmail,
+		now:             time.Now,
+	}
+}
+
+// maxActiveApplications membaca batas formulir aktif per pengguna dari MAXACTIVEAPPLICATIONS.
+func maxActiveApplications() int {
+	value, err := strconv.Atoi(os.Getenv("MAXACTIVEAPPLICATIONS"))
+	if err != nil || value <= 0 {
+		return DefaultMaxActiveApplications
+	}
+	return value
+}
+
+// validateForm memeriksa field tetap formulir dan jawaban pertanyaan tambahan lowongan, lalu
+// mengembalikan jawaban yang sudah dinormalisasi. Berkas hanya dapat dilampirkan pada draft,
+// sehingga lowongan dengan pertanyaan file wajib harus didaftar melalui alur draft.
+func validateForm(form *entity.Internship_ApplicationForm, listing *entity.Internship_Listing) ([]entity.ApplicationAnswer, error) {
+	answers, invalidData := questions.Check(listing.Questions, form.Answers)
+	for field, message := range formErrors(form) {
+		invalidData[field] = message
+	}
+	for _, question := range listing.Questions {
+		field := "answers." + question.Key
+		if _, invalid := invalidData[field]; !invalid && question.Type == constants.QuestionFile && question.Required {
+			invalidData[field] = "Berkas jawaban wajib diunggah melalui draft formulir"
+		}
+	}
+	if len(invalidData) > 0 {
+		return nil, &ValidationError{Fields: invalidData}
+	}
+	return answers, nil
+}
+
+// formErrors memeriksa field tetap formulir yang wajib diisi.
+func formErrors(form *entity.Internship_ApplicationForm) map[string]string {
+	invalidData := make(map[string]string)
+	if form.Nim == "" {
+		invalidData["nim"] = "Nim is required"
+	}
+	if form.GPA <= 0 {
+		invalidData["gpa"] = "GPA must be greater than 0"
+	}
+	if form.EducationLevel == "" {
+		invalidData["education_level"] = "Education level is required"
+	}
+	return invalidData
+}
+
+// findListing mencari lowongan berdasarkan ID.
+func (uc *applicationUsecase) findListing(listingID uint) (*entity.Internship_Listing, error) {
+	listing, err := uc.listings.FindByID(listingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrListingNotFound
+		}
+		return nil, err
+	}
+	return listing, nil
+}
+
+// findOpenListing mencari lowongan berdasarkan ID yang sedang menerima pendaftaran.
+func (uc *applicationUsecase) findOpenListing(listingID uint) (*entity.Internship_Listing, error) {
+	if listingID == 0 {
+		return nil, &ValidationError{Fields: map[string]string{
+			"internshiplistingID": "ID penawaran magang wajib diisi",
+		}}
+	}
+	listing, err := uc.findListing(listingID)
+	if err != nil {
+		return nil, err
+	}
+	if !liststatus.AcceptsApplications(listing, uc.now()) {
+		return nil, ErrListingClosed
+	}
+	return listing, nil
+}
+
+// Apply mengajukan formulir atas nama applicant untuk lowongan form.InternshipListingID yang sedang
+// menerima pendaftaran, dengan jawaban yang divalidasi terhadap pertanyaan lowongan. Repository
+// memakai kuota secara atomik dan membatasi satu formulir per lowongan serta MAXACTIVEAPPLICATIONS
+// formulir aktif per pengguna. SelectedTitle hanya menyalin judul lowongan saat pendaftaran.
+func (uc *applicationUsecase) Apply(applicant *entity.User, form *entity.Internship_ApplicationForm) (*entity.Internship_ApplicationForm, error) {
+	listing, err := uc.findOpenListing(form.InternshipListingID)
+	if err != nil {
+		return nil, err
+	}
+	answers, err := validateForm(form, listing)
+	if err != nil {
+		return nil, err
+	}
+	if listing.Quota <= 0 {
+		return nil, ErrQuotaFull
+	}
+
+	application := entity.Internship_ApplicationForm{
+		Nim:                 form.Nim,
+		GPA:                 form.GPA,
+		EducationLevel:      form.EducationLevel,
+		Major:               applicant.Major,
+		Status:              constants.StatusPending,
+		UserID:              applicant.ID,
+		UserEmail:           applicant.Email,
+		Username:            applicant.Username,
+		SelectedTitle:       listing.Title,
+		InternshipListingID: listing.ID,
+		Answers:             answers,
+	}
+	if err := uc.applications.Submit(&application, maxActiveApplications()); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrConflict):
+			return nil, ErrAlreadyApplied
+		case errors.Is(err, repository.ErrLimitExceeded):
+			return nil, ErrTooManyActive
+		case errors.Is(err, repository.ErrQuotaFull):
+			return nil, ErrQuotaFull
+		case errors.Is(err, repository.ErrNotFound):
+			return nil, ErrListingNotFound
+		}
+		return nil, err
+	}
+	return &application, nil
+}
+
+// Cancel membatalkan formulir dan mengembalikan satu slot kuota ke lowongannya. Jika formulir
+// sudah diterima, kursinya diberikan kepada kandidat daftar tunggu berikutnya.
+func (uc *applicationUsecase) Cancel(application *entity.Internship_ApplicationForm, actor Actor) error {
+	if application.Status == constants.StatusCanceled {
+		return ErrAlreadyCanceled
+	}
+	return uc.transition(application, constants.StatusCanceled, actor, "")
+}
+
+// Transition memindahkan formulir ke status to jika diizinkan state machine dan mencatat alasannya.
+// Kandidat yang diterima mendapat offer yang harus dijawab sebelum masa berlakunya habis, dan
+// penerimaan ditolak dengan ErrSeatsFull jika kursi lowongan sudah penuh.
+func (uc *applicationUsecase) Transition(id uint, to string, actor Actor, reason string) (*entity.Internship_ApplicationForm, error) {
+	application, err := uc.GetApplication(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.transition(application, to, actor, reason); err != nil {
+		return nil, err
+	}
+	return application, nil
+}
+
+func (uc *applicationUsecase) transition(application *entity.Internship_ApplicationForm, to string, actor Actor, reason string) error {
+	if err := status.Check(application.Status, to); err != nil {
+		return err
+	}
+
+	change := repository.StatusChange{
+		Application: application,
+		History: &entity.ApplicationStatusHistory{
+			FromStatus:    application.Status,
+			ToStatus:      to,
+			ChangedByType: actor.Type,
+			ChangedByID:   actor.ID,
+			Reason:        reason,
+		},
+	}
+	if to == constants.StatusAccepted {
+		change.Offer = uc.newOffer()
+	}
+	err := uc.applications.TransitionMany([]repository.StatusChange{change})
+	switch {
+	case errors.Is(err, repository.ErrConflict):
+		return ErrStatusChanged
+	case errors.Is(err, repository.ErrSeatsFull):
+		return ErrSeatsFull
+	case errors.Is(err, repository.ErrNotFound):
+		return ErrApplicationNotFound
+	case err != nil:
+		return err
+	}
+
+	if change.History.FromStatus == constants.StatusAccepted && to == constants.StatusCanceled {
+		uc.promoteNext(application.InternshipListingID)
+	}
+	return nil
+}
+
+func (uc *applicationUsecase) GetApplication(id uint) (*entity.Internship_ApplicationForm, error) {
+	application, err := uc.applications.FindByID(id)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrApplicationNotFound
+	}
+	return application, err
+}
+
+// GetApplications mengembalikan semua formulir yang sudah diajukan; draft tidak termasuk.
+func (uc *applicationUsecase) GetApplications() ([]entity.Internship_ApplicationForm, error) {
+	applications, err := uc.applications.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	submitted := make([]entity.Internship_ApplicationForm, 0, len(applications))
+	for _, application := range applications {
+		if application.Status != constants.StatusDraft {
+			submitted = append(submitted, application)
+		}
+	}
+	return submitted, nil
+}
+
+func (uc *applicationUsecase) GetStatusHistory(id uint) ([]entity.ApplicationStatusHistory, error) {
+	if _, err := uc.GetApplication(id); err != nil {
+		return nil, err
+	}
+	return uc.applications.FindStatusHistory(id)
+}
+
+// PreviewScreening mengevaluasi formulir terhadap kriteria lowongannya tanpa mengubah status.
+func (uc *applicationUsecase) PreviewScreening(id uint) (*screening.Result, error) {
+	application, err := uc.GetApplication(id)
+	if err != nil {
+		return nil, err
+	}
+	return uc.evaluate(application)
+}
+
+// Screen menerima kandidat terverifikasi yang lolos semua kriteria lowongan dan menolak kandidat
+// yang gagal, dengan ringkasan aturan yang gagal sebagai alasan penolakan. Perubahan tetap harus
+// diizinkan state machine, misalnya kandidat yang belum diverifikasi tidak dapat langsung diterima.
+func (uc *applicationUsecase) Screen(id uint, actor Actor) (*entity.Internship_ApplicationForm, *screening.Result, error) {
+	candidate, err := uc.GetApplication(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	result, err := uc.evaluate(candidate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	to, reason := constants.StatusRejected, result.FailureSummary()
+	if result.Passed {
+		to, reason = constants.StatusAccepted, "Lolos semua kriteria penyaringan"
+	}
+	if err := uc.transition(candidate, to, actor, reason); err != nil {
+		return nil, result, err
+	}
+	return candidate, result, nil
+}
+
+func (uc *applicationUsecase) evaluate(application *entity.Internship_ApplicationForm) (*screening.Result, error) {
+	listing, err := uc.listings.FindByID(application.InternshipListingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrListingNotFound
+		}
+		return nil, err
+	}
+	result := screening.Evaluate(listing.ScreeningCriteria, application)
+	return &result, nil
+}
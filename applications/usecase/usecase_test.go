@@ -0,0 +1,349 @@
+package usecase
+
+import (
+	"miniproject/applications/screening"
+	"miniproject/constants"
+	"miniproject/entity"
+	"miniproject/repository"
+	"miniproject/repository/memory"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestUsecase(t *testing.T, quota int) (*repository.Repositories, ApplicationUsecase, *entity.Internship_Listing) {
+	repos := memory.NewRepositories()
+	listing := &entity.Internship_Listing{Title: "Backend Developer", Quota: quota, Status: constants.ListingPublished}
+	assert.NoError(t, repos.Listings.Create(listing))
+	return repos, NewApplicationUsecase(repos.Applications, repos.Listings, repos.Offers), listing
+}
+
+func applicant(id uint) *entity.User {
+	user := &entity.User{Username: "rara", Email: "rara@gmail.com"}
+	user.ID = id
+	return user
+}
+
+var (
+	userActor  = Actor{Type: constants.SubjectUser, ID: 1}
+	adminActor = Actor{Type: constants.SubjectAdmin, ID: 1}
+)
+
+func validForm(listingID uint) *entity.Internship_ApplicationForm {
+	return &entity.Internship_ApplicationForm{
+		Nim:                 "123456",
+		GPA:                 3.8,
+		EducationLevel:      "S1",
+		InternshipListingID: listingID,
+	}
+}
+
+func TestApplyDecrementsQuota(t *testing.T) {
+	repos, uc, listing := newTestUsecase(t, 2)
+
+	application, err := uc.Apply(applicant(1), validForm(listing.ID))
+	if assert.NoError(t, err) {
+		assert.Equal(t, listing.ID, application.InternshipListingID)
+		assert.Equal(t, uint(1), application.UserID)
+		assert.Equal(t, "rara@gmail.com", application.UserEmail)
+	}
+
+	updated, err := repos.Listings.FindByID(listing.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, updated.Quota)
+}
+
+func TestApplyRequiresOpenListing(t *testing.T) {
+	repos, uc, listing := newTestUsecase(t, 5)
+
+	assert.NoError(t, repos.Listings.UpdateStatus(listing.ID, constants.ListingPublished, constants.ListingClosed))
+	_, err := uc.Apply(applicant(1), validForm(listing.ID))
+	assert.ErrorIs(t, err, ErrListingClosed)
+
+	deadline := time.Now().Add(-time.Hour)
+	listing.Status = constants.ListingPublished
+	listing.ApplicationDeadline = &deadline
+	assert.NoError(t, repos.Listings.Save(listing))
+	_, err = uc.Apply(applicant(1), validForm(listing.ID))
+	assert.ErrorIs(t, err, ErrListingClosed)
+}
+
+func TestApplyRejectsInvalidForm(t *testing.T) {
+	_, uc, listing := newTestUsecase(t, 1)
+	form := validForm(listing.ID)
+	form.Nim = ""
+	form.GPA = 0
+
+	_, err := uc.Apply(applicant(1), form)
+	var validationErr *ValidationError
+	if assert.ErrorAs(t, err, &validationErr) {
+		assert.Contains(t, validationErr.Fields, "nim")
+		assert.Contains(t, validationErr.Fields, "gpa")
+	}
+}
+
+func TestApplyQuotaFull(t *testing.T) {
+	repos, uc, listing := newTestUsecase(t, 0)
+
+	_, err := uc.Apply(applicant(1), validForm(listing.ID))
+	assert.ErrorIs(t, err, ErrQuotaFull)
+
+	applications, err := repos.Applications.FindAll()
+	assert.NoError(t, err)
+	assert.Empty(t, applications)
+}
+
+func TestApplyConcurrentDoesNotOversubscribe(t *testing.T) {
+	const quota, applicants = 5, 50
+	repos, uc, listing := newTestUsecase(t, quota)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, applicants)
+	for i := 0; i < applicants; i++ {
+		wg.Add(1)
+		user := applicant(uint(i + 1))
+		go func() {
+			defer wg.Done()
+			_, err := uc.Apply(user, validForm(listing.ID))
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	accepted := 0
+	for err := range errs {
+		if err == nil {
+			accepted++
+			continue
+		}
+		assert.ErrorIs(t, err, ErrQuotaFull)
+	}
+	assert.Equal(t, quota, accepted)
+
+	updated, err := repos.Listings.FindByID(listing.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, updated.Quota)
+
+	applications, err := repos.Applications.FindAll()
+	assert.NoError(t, err)
+	assert.Len(t, applications, quota)
+}
+
+func TestApplyRejectsDuplicate(t *testing.T) {
+	repos, uc, listing := newTestUsecase(t, 5)
+
+	application, err := uc.Apply(applicant(1), validForm(listing.ID))
+	assert.NoError(t, err)
+
+	_, err = uc.Apply(applicant(1), validForm(listing.ID))
+	assert.ErrorIs(t, err, ErrAlreadyApplied)
+
+	updated, err := repos.Listings.FindByID(listing.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 4, updated.Quota)
+
+	// Formulir yang sudah dibatalkan tidak menghalangi pendaftaran ulang
+	assert.NoError(t, uc.Cancel(application, userActor))
+	_, err = uc.Apply(applicant(1), validForm(listing.ID))
+	assert.NoError(t, err)
+}
+
+func TestApplyActiveLimit(t *testing.T) {
+	t.Setenv("MAXACTIVEAPPLICATIONS", "2")
+	repos, uc, _ := newTestUsecase(t, 5)
+
+	for i, title := range []string{"Frontend Developer", "Data Analyst", "QA Engineer"} {
+		listing := &entity.Internship_Listing{Title: title, Quota: 5, Status: constants.ListingPublished}
+		assert.NoError(t, repos.Listings.Create(listing))
+		_, err := uc.Apply(applicant(1), validForm(listing.ID))
+		if i < 2 {
+			assert.NoError(t, err)
+		} else {
+			assert.ErrorIs(t, err, ErrTooManyActive)
+		}
+	}
+}
+
+func TestApplyUnknownListing(t *testing.T) {
+	_, uc, _ := newTestUsecase(t, 1)
+
+	_, err := uc.Apply(applicant(1), validForm(99))
+	assert.ErrorIs(t, err, ErrListingNotFound)
+
+	_, err = uc.Apply(applicant(1), validForm(0))
+	var validationErr *ValidationError
+	if assert.ErrorAs(t, err, &validationErr) {
+		assert.Contains(t, validationErr.Fields, "internshiplistingID")
+	}
+}
+
+func TestApplyAllowsRepeatedTitles(t *testing.T) {
+	repos, uc, listing := newTestUsecase(t, 1)
+	sameTitle := &entity.Internship_Listing{Title: listing.Title, Quota: 1, Status: constants.ListingPublished}
+	assert.NoError(t, repos.Listings.Create(sameTitle))
+
+	form := validForm(sameTitle.ID)
+	form.SelectedTitle = "Judul Salah"
+	application, err := uc.Apply(applicant(1), form)
+	if assert.NoError(t, err) {
+		assert.Equal(t, sameTitle.ID, application.InternshipListingID)
+		assert.Equal(t, listing.Title, application.SelectedTitle)
+	}
+
+	// Judul yang tersimpan adalah snapshot dan tidak ikut berubah saat lowongan diganti namanya
+	assert.NoError(t, repos.Listings.Update(sameTitle.ID, &entity.Internship_Listing{Title: "Backend Developer 2025"}))
+	stored, err := repos.Applications.FindByID(application.ID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, listing.Title, stored.SelectedTitle)
+	}
+}
+
+func TestCancelRefundsQuota(t *testing.T) {
+	repos, uc, listing := newTestUsecase(t, 1)
+	application, err := uc.Apply(applicant(1), validForm(listing.ID))
+	assert.NoError(t, err)
+
+	assert.NoError(t, uc.Cancel(application, userActor))
+	assert.Equal(t, constants.StatusCanceled, application.Status)
+
+	updated, err := repos.Listings.FindByID(listing.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, updated.Quota)
+
+	assert.ErrorIs(t, uc.Cancel(application, userActor), ErrAlreadyCanceled)
+}
+
+func TestScreen(t *testing.T) {
+	repos, uc, listing := newTestUsecase(t, 5)
+	listing.Seats = 2
+	assert.NoError(t, repos.Listings.Save(listing))
+	cases := []struct {
+		gpa    float64
+		status string
+	}{
+		{3.8, constants.StatusAccepted},
+		{3.5, constants.StatusAccepted},
+		{3.2, constants.StatusRejected},
+	}
+
+	for _, tc := range cases {
+		application := &entity.Internship_ApplicationForm{GPA: tc.gpa, Status: constants.StatusVerified, InternshipListingID: listing.ID}
+		assert.NoError(t, repos.Applications.Create(application))
+
+		selected, result, err := uc.Screen(application.ID, adminActor)
+		if assert.NoError(t, err) {
+			assert.Equal(t, tc.status, selected.Status)
+			assert.Equal(t, tc.status == constants.StatusAccepted, result.Passed)
+		}
+	}
+
+	full := &entity.Internship_ApplicationForm{GPA: 3.9, Status: constants.StatusVerified, InternshipListingID: listing.ID}
+	assert.NoError(t, repos.Applications.Create(full))
+	_, result, err := uc.Screen(full.ID, adminActor)
+	assert.ErrorIs(t, err, ErrSeatsFull)
+	assert.True(t, result.Passed)
+
+	_, _, err = uc.Screen(999, adminActor)
+	assert.ErrorIs(t, err, ErrApplicationNotFound)
+}
+
+func TestScreenUsesListingCriteria(t *testing.T) {
+	repos, uc, _ := newTestUsecase(t, 5)
+	listing := &entity.Internship_Listing{Title: "Data Analyst", Quota: 5, ScreeningCriteria: &entity.ScreeningCriteria{
+		MinGPA:          3.0,
+		EducationLevels: []string{"S1"},
+		Majors:          []string{"Statistika"},
+	}}
+	assert.NoError(t, repos.Listings.Create(listing))
+	application := &entity.Internship_ApplicationForm{
+		GPA: 3.2, EducationLevel: "D3", Major: "Statistika", Status: constants.StatusVerified, InternshipListingID: listing.ID,
+	}
+	assert.NoError(t, repos.Applications.Create(application))
+
+	rejected, result, err := uc.Screen(application.ID, adminActor)
+	if assert.NoError(t, err) {
+		assert.Equal(t, constants.StatusRejected, rejected.Status)
+		assert.False(t, result.Passed)
+	}
+
+	// Alasan penolakan dicatat pada riwayat status
+	history, err := uc.GetStatusHistory(application.ID)
+	if assert.NoError(t, err) && assert.Len(t, history, 1) {
+		assert.Contains(t, history[0].Reason, "Jenjang pendidikan")
+		assert.NotContains(t, history[0].Reason, "IPK")
+	}
+}
+
+func TestScreenUsesUploadedCV(t *testing.T) {
+	repos, uc, _ := newTestUsecase(t, 5)
+	listing := &entity.Internship_Listing{Title: "Data Analyst", Quota: 5, Seats: 1, ScreeningCriteria: &entity.ScreeningCriteria{
+		Rules: []entity.ScreeningRule{{Name: "wajib_cv", Fact: screening.FactHasCV, Expected: true}},
+	}}
+	assert.NoError(t, repos.Listings.Create(listing))
+	application := &entity.Internship_ApplicationForm{
+		CV: "cv.pdf", GPA: 3.2, Status: constants.StatusVerified, InternshipListingID: listing.ID,
+	}
+	assert.NoError(t, repos.Applications.Create(application))
+
+	// CV berupa teks bebas tidak dihitung
+	result, err := uc.PreviewScreening(application.ID)
+	if assert.NoError(t, err) {
+		assert.False(t, result.Passed)
+	}
+
+	assert.NoError(t, repos.Documents.Create(&entity.ApplicationDocument{
+		InternshipApplicationFormID: application.ID, Type: constants.DocumentCV, FileName: "cv.pdf",
+	}))
+	accepted, result, err := uc.Screen(application.ID, adminActor)
+	if assert.NoError(t, err) {
+		assert.True(t, result.Passed)
+		assert.Equal(t, constants.StatusAccepted, accepted.Status)
+	}
+}
+
+func TestScreenRequiresVerification(t *testing.T) {
+	_, uc, listing := newTestUsecase(t, 5)
+	application, err := uc.Apply(applicant(1), validForm(listing.ID))
+	assert.NoError(t, err)
+
+	_, _, err = uc.Screen(application.ID, adminActor)
+	assert.ErrorIs(t, err, ErrInvalidTransition)
+}
+
+func TestTransitionRecordsHistory(t *testing.T) {
+	_, uc, listing := newTestUsecase(t, 5)
+	application, err := uc.Apply(applicant(1), validForm(listing.ID))
+	assert.NoError(t, err)
+	assert.Equal(t, constants.StatusPending, application.Status)
+
+	_, err = uc.Transition(application.ID, constants.StatusAccepted, adminActor, "langsung diterima")
+	assert.ErrorIs(t, err, ErrInvalidTransition)
+
+	updated, err := uc.Transition(application.ID, constants.StatusVerified, adminActor, "dokumen lengkap")
+	if assert.NoError(t, err) {
+		assert.Equal(t, constants.StatusVerified, updated.Status)
+	}
+
+	history, err := uc.GetStatusHistory(application.ID)
+	if assert.NoError(t, err) && assert.Len(t, history, 2) {
+		assert.Equal(t, constants.StatusPending, history[0].ToStatus)
+		assert.Equal(t, constants.SubjectUser, history[0].ChangedByType)
+		assert.Equal(t, constants.StatusPending, history[1].FromStatus)
+		assert.Equal(t, constants.StatusVerified, history[1].ToStatus)
+		assert.Equal(t, constants.SubjectAdmin, history[1].ChangedByType)
+		assert.Equal(t, "dokumen lengkap", history[1].Reason)
+	}
+}
+
+func TestCanceledIsTerminal(t *testing.T) {
+	_, uc, listing := newTestUsecase(t, 5)
+	application, err := uc.Apply(applicant(1), validForm(listing.ID))
+	assert.NoError(t, err)
+	assert.NoError(t, uc.Cancel(application, userActor))
+
+	_, err = uc.Transition(application.ID, constants.StatusVerified, adminActor, "dibuka kembali")
+	assert.ErrorIs(t, err, ErrInvalidTransition)
+}
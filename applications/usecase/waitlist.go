@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"errors"
+	"log"
+	"miniproject/entity"
+	"miniproject/helpers"
+	"miniproject/repository"
+)
+
+// PromotionNotifier mengirim pemberitahuan kepada kandidat yang dipromosikan dari daftar tunggu.
+type PromotionNotifier func(application *entity.Internship_ApplicationForm, offer *entity.Offer) error
+
+func notifyPromotionByEmail(application *entity.Internship_ApplicationForm, offer *entity.Offer) error {
+	return helpers.SendWaitlistPromotionEmail(application.UserEmail, application.Username,
+		application.SelectedTitle, offer.ID, offer.ExpiresAt)
+}
+
+// promoteNext mengisi kursi yang dilepas kandidat diterima dengan kandidat daftar tunggu
+// berperingkat terbaik dan mengirimkan offer kepadanya. Kegagalan promosi tidak membatalkan
+// perubahan yang memicunya sehingga hanya dicatat ke log; kursi tetap dapat diisi oleh
+// ExpireOffers atau seleksi berikutnya.
+func (uc *applicationUsecase) promoteNext(listingID uint) {
+	offer := uc.newOffer()
+	history := entity.ApplicationStatusHistory{
+		ChangedByType: systemActor.Type,
+		ChangedByID:   systemActor.ID,
+		Reason:        "Dipromosikan otomatis dari daftar tunggu",
+	}
+	promoted, err := uc.applications.PromoteNext(listingID, offer, &history)
+	if err != nil {
+		if !errors.Is(err, repository.ErrNotFound) {
+			log.Println("gagal mempromosikan kandidat daftar tunggu:", err)
+		}
+		return
+	}
+	if err := uc.notifyPromotion(promoted, offer); err != nil {
+		log.Println("gagal mengirim email promosi daftar tunggu:", err)
+	}
+}
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"miniproject/constants"
+	"miniproject/entity"
+	"miniproject/repository"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newWaitlistUsecase menyiapkan lowongan satu kursi dengan satu kandidat diterima dan dua
+// kandidat daftar tunggu, serta mencatat kandidat yang diberi tahu.
+func newWaitlistUsecase(t *testing.T, now time.Time) (*repository.Repositories, *applicationUsecase, []*entity.Internship_ApplicationForm, *[]uint) {
+	repos, uc, listing := newTestUsecase(t, 10)
+	listing.Seats = 1
+	assert.NoError(t, repos.Listings.Save(listing))
+
+	applications := []*entity.Internship_ApplicationForm{
+		{UserID: 1, Status: constants.StatusAccepted, SelectionRank: 1},
+		{UserID: 2, Status: constants.StatusWaitlisted, SelectionRank: 3},
+		{UserID: 3, Status: constants.StatusWaitlisted, SelectionRank: 2},
+	}
+	for _, application := range applications {
+		application.InternshipListingID = listing.ID
+		assert.NoError(t, repos.Applications.Create(application))
+	}
+
+	notified := []uint{}
+	impl := uc.(*applicationUsecase)
+	impl.now = func() time.Time { return now }
+	impl.notifyPromotion = func(application *entity.Internship_ApplicationForm, offer *entity.Offer) error {
+		notified = append(notified, application.ID)
+		return nil
+	}
+	return repos, impl, applications, &notified
+}
+
+func TestCancelAcceptedPromotesBestRankedWaitlisted(t *testing.T) {
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	repos, uc, applications, notified := newWaitlistUsecase(t, now)
+
+	assert.NoError(t, uc.Cancel(applications[0], userActor))
+
+	assert.Equal(t, []string{
+		constants.StatusCanceled,
+		constants.StatusWaitlisted,
+		constants.StatusAccepted,
+	}, statuses(t, repos, applications))
+	assert.Equal(t, []uint{applications[2].ID}, *notified)
+
+	offers, err := uc.GetUserOffers(3)
+	if assert.NoError(t, err) && assert.Len(t, offers, 1) {
+		assert.Equal(t, applications[2].ID, offers[0].InternshipApplicationFormID)
+		assert.Equal(t, constants.OfferPending, offers[0].Status)
+		assert.Equal(t, now.Add(DefaultOfferValidHours*time.Hour), offers[0].ExpiresAt)
+	}
+
+	history, err := uc.GetStatusHistory(applications[2].ID)
+	if assert.NoError(t, err) && assert.Len(t, history, 1) {
+		assert.Equal(t, constants.StatusWaitlisted, history[0].FromStatus)
+		assert.Equal(t, constants.StatusAccepted, history[0].ToStatus)
+		assert.Equal(t, constants.SubjectSystem, history[0].ChangedByType)
+	}
+}
+
+func TestCancelWaitlistedDoesNotPromote(t *testing.T) {
+	repos, uc, applications, notified := newWaitlistUsecase(t, time.Now())
+
+	assert.NoError(t, uc.Cancel(applications[2], userActor))
+
+	assert.Equal(t, []string{
+		constants.StatusAccepted,
+		constants.StatusWaitlisted,
+		constants.StatusCanceled,
+	}, statuses(t, repos, applications))
+	assert.Empty(t, *notified)
+}
@@ -1,16 +1,59 @@
 package constants
 
 const (
-	StatusPending  = "pending"
-	StatusVerified = "verified"
-	StatusAccepted = "accepted"
-	StatusRejected = "rejected"
-	StatusCanceled = "canceled"
+	StatusDraft      = "draft"
+	StatusPending    = "pending"
+	StatusVerified   = "verified"
+	StatusAccepted   = "accepted"
+	StatusRejected   = "rejected"
+	StatusCanceled   = "canceled"
+	StatusWaitlisted = "waitlisted"
 
+	OfferPending   = "pending"
+	OfferAccepted  = "accepted"
+	OfferDeclined  = "declined"
+	OfferExpired   = "expired"
+	OfferWithdrawn = "withdrawn"
 
+	ListingDraft     = "draft"
+	ListingPublished = "published"
+	ListingClosed    = "closed"
+	ListingArchived  = "archived"
+
+	ListingSortNewest    = "newest"
+	ListingSortOldest    = "oldest"
+	ListingSortDeadline  = "deadline"
+	ListingSortStartDate = "start_date"
+	ListingSortTitle     = "title"
+
+	DocumentCV             = "cv"
+	DocumentTranscript     = "transcript"
+	DocumentRecommendation = "recommendation_letter"
+	DocumentAnswer         = "question_answer"
+
+	QuestionText   = "text"
+	QuestionChoice = "choice"
+	QuestionNumber = "number"
+	QuestionFile   = "file"
+
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+
+	SubjectUser   = "user"
+	SubjectAdmin  = "admin"
+	SubjectSystem = "system"
 
 	ErrUserAlreadyExists   = "Pengguna sudah terdaftar dengan email ini"
 	ErrFailedToRegister    = "Gagal mendaftarkan pengguna"
 	ErrFailedToLogIn       = "Failed to log in"
+	ErrInvalidCredentials  = "Username atau password salah"
+	ErrTooManyAttempts     = "Terlalu banyak percobaan login, silakan coba lagi nanti"
+	ErrLoginUnavailable    = "Login sementara tidak dapat diproses, silakan coba lagi nanti"
+	ErrTooManyResets       = "Terlalu banyak permintaan reset password, silakan coba lagi nanti"
+	ErrResetUnavailable    = "Reset password sementara tidak dapat diproses, silakan coba lagi nanti"
 	ErrTokenCreationFailed = "Gagal membuat token"
+	ErrUnauthorized        = "Token tidak valid atau sudah kedaluwarsa"
+	ErrForbidden           = "Anda tidak memiliki akses ke resource ini"
+	ErrEmailNotVerified    = "Email belum diverifikasi, silakan verifikasi email terlebih dahulu"
+	ErrAdminInviteOnly     = "Registrasi admin hanya dapat dilakukan melalui undangan"
 )
@@ -1,33 +1,86 @@
 package controllers
 
 import (
+	"errors"
 	"fmt"
+	"log"
+	applicationusecase "miniproject/applications/usecase"
 	"miniproject/constants"
+	documentusecase "miniproject/documents/usecase"
 	"miniproject/entity"
 	"miniproject/helpers"
-	"miniproject/infra/config"
+	"miniproject/infra/storage"
+	liststatus "miniproject/listings/status"
+	listingusecase "miniproject/listings/usecase"
 	"miniproject/middleware"
+	"miniproject/repository"
 	"net/http"
 	"strconv"
 
 	"github.com/labstack/echo/v4"
 )
 
+// AdminHandler menangani rute akun admin, pengelolaan lowongan, dan seleksi kandidat.
+type AdminHandler struct {
+	Admins         repository.AdminRepository
+	Invitations    repository.InvitationRepository
+	PasswordResets repository.PasswordResetRepository
+	Listings       listingusecase.ListingUsecase
+	Applications   applicationusecase.ApplicationUsecase
+	Documents      documentusecase.DocumentUsecase
+	Auth           *middleware.Auth
+	Throttle       *helpers.LoginThrottler
+}
+
+func NewAdminHandler(repos *repository.Repositories, store storage.Storage, auth *middleware.Auth, throttle *helpers.LoginThrottler) *AdminHandler {
+	return &AdminHandler{
+		Admins:         repos.Admins,
+		Invitations:    repos.Invitations,
+		PasswordResets: repos.PasswordResets,
+		Listings:       listingusecase.NewListingUsecase(repos.Listings),
+		Applications:   applicationusecase.NewApplicationUsecase(repos.Applications, repos.Listings, repos.Offers),
+		Documents:      documentusecase.NewDocumentUsecase(repos.Documents, store),
+		Auth:           auth,
+		Throttle:       throttle,
+	}
+}
+
 // pengguna akun
 
-func RegisterAdmin(c echo.Context) error {
-	admin := entity.Admin{}
-	if err := c.Bind(&admin); err != nil {
+// adminRequest adalah data akun admin dari request body. Hash password tidak pernah dikirim di
+// respons (json:"-"), sehingga password dari request dibaca melalui field terpisah.
+type adminRequest struct {
+	entity.Admin
+	Password string `json:"password" form:"password"`
+}
+
+// Fungsi RegisterAdmin hanya digunakan untuk bootstrap admin pertama. Setelah ada admin,
+// akun admin baru hanya dapat dibuat melalui undangan (lihat AcceptAdminInvitationController).
+func (h *AdminHandler) RegisterAdmin(c echo.Context) error {
+	adminCount, err := h.Admins.Count()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": constants.ErrFailedToRegister,
+			"error":   err.Error(),
+		})
+	}
+	if adminCount > 0 {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"message": constants.ErrAdminInviteOnly,
+		})
+	}
+
+	request := adminRequest{}
+	if err := c.Bind(&request); err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]interface{}{
 			"message": "Invalid admin data",
 			"error":   err.Error(),
 		})
 	}
+	admin := request.Admin
 
 	// Cek apakah pengguna sudah terdaftar berdasarkan alamat email
-	var existingUser entity.Admin
-	err := config.DB.Where("email = ?", admin.Email).First(&existingUser).Error
-	if err == nil {
+	if _, err := h.Admins.FindByEmail(admin.Email); err == nil {
 		return c.JSON(http.StatusBadRequest, map[string]interface{}{
 			"message": constants.ErrUserAlreadyExists,
 		})
@@ -35,15 +88,31 @@ func RegisterAdmin(c echo.Context) error {
 
 	// Atur peran pengguna menjadi 'user' (jika tidak sudah diset)
 	admin.Role = "admin"
+	admin.TOTPEnabled = false
 
-	// Jika pengguna belum terdaftar, simpan data pendaftaran ke dalam basis data
-	err = config.DB.Create(&admin).Error
+	// Simpan password dalam bentuk hash, bukan plaintext
+	hashedPassword, err := helpers.HashPassword(request.Password)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
 			"message": constants.ErrFailedToRegister,
 			"error":   err.Error(),
 		})
 	}
+	admin.Password = hashedPassword
+
+	// Pemeriksaan jumlah admin di atas hanya jalur cepat; CreateFirst memastikan hanya satu
+	// bootstrap yang berhasil walaupun ada request paralel
+	if err := h.Admins.CreateFirst(&admin); err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			return c.JSON(http.StatusForbidden, map[string]interface{}{
+				"message": constants.ErrAdminInviteOnly,
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": constants.ErrFailedToRegister,
+			"error":   err.Error(),
+		})
+	}
 
 	// Mengirim respons HTTP berhasil setelah pengguna berhasil didaftarkan
 	return c.JSON(http.StatusOK, map[string]interface{}{
@@ -53,26 +122,92 @@ func RegisterAdmin(c echo.Context) error {
 }
 
 // Fungsi LoginAdminController digunakan untuk mengautentikasi admin dan memberikan token akses jika berhasil.
-func LoginAdminController(c echo.Context) error {
-	admin := entity.Admin{}
-	if err := c.Bind(&admin); err != nil {
+func (h *AdminHandler) LoginAdminController(c echo.Context) error {
+	request := adminRequest{}
+	if err := c.Bind(&request); err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]interface{}{
 			"message": "Fail to parse request body",
 			"error":   err.Error(),
 		})
 	}
+	admin := request.Admin
 
-	// Mencari admin dalam basis data berdasarkan alamat email dan kata sandi
-	err := config.DB.Where("Username = ? AND password = ?", admin.Username, admin.Password).First(&admin).Error
+	// Tolak sementara jika username atau IP sedang dikunci / masih dalam jeda progresif
+	throttleKeys := helpers.LoginThrottleKeys(constants.SubjectAdmin, admin.Username, c.RealIP())
+	retryAfter, err := h.Throttle.Check(throttleKeys)
 	if err != nil {
+		return loginUnavailable(c, err)
+	}
+	if retryAfter > 0 {
+		return tooManyLoginAttempts(c, retryAfter)
+	}
+
+	// Mencari admin dalam basis data berdasarkan username, lalu verifikasi kata sandi
+	password := request.Password
+	match, needsRehash := false, false
+	if existingAdmin, err := h.Admins.FindByUsername(admin.Username); err == nil {
+		admin = *existingAdmin
+		match, needsRehash = helpers.VerifyPassword(admin.Password, password)
+	} else {
+		helpers.DummyVerifyPassword(password)
+	}
+	if !match {
+		if err := h.Throttle.RecordFailure(throttleKeys); err != nil {
+			log.Println("gagal mencatat percobaan login:", err)
+		}
 		return c.JSON(http.StatusUnauthorized, map[string]interface{}{
-			"message": constants.ErrFailedToLogIn,
-			"error":   err.Error(),
+			"message": constants.ErrInvalidCredentials,
 		})
 	}
 
+	// Password lama (plaintext) di-hash ulang secara otomatis saat login berhasil
+	if needsRehash {
+		if hashedPassword, err := helpers.HashPassword(password); err == nil {
+			admin.Password = hashedPassword
+			admin.PasswordResetRequired = false
+			if err := h.Admins.Save(&admin); err != nil {
+				log.Println("gagal menyimpan hash password baru:", err)
+			}
+		}
+	}
+
+	// Admin dengan 2FA aktif harus menukar challenge token dengan kode TOTP terlebih dahulu.
+	// Hitungan gagal belum direset agar percobaan kode 2FA tetap dibatasi.
+	if admin.TOTPEnabled {
+		challengeToken, err := middleware.CreateMFAChallengeToken(admin.ID)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+				"message": constants.ErrTokenCreationFailed,
+				"error":   err.Error(),
+			})
+		}
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"message":         "Two-factor authentication required",
+			"mfa_required":    true,
+			"challenge_token": challengeToken,
+		})
+	}
+
+	return h.adminLoginResponse(c, &admin)
+}
+
+// adminLoginResponse menerbitkan access token dan refresh token untuk admin yang sudah terautentikasi
+// penuh, lalu menghapus hitungan login gagal milik username tersebut.
+func (h *AdminHandler) adminLoginResponse(c echo.Context, admin *entity.Admin) error {
+	if err := h.Throttle.Reset(helpers.LoginThrottleKey(constants.SubjectAdmin, admin.Username)); err != nil {
+		log.Println("gagal mereset percobaan login:", err)
+	}
+
 	// Menghasilkan token akses untuk admin
-	token, err := middleware.CreateToken(admin.ID, admin.Username)
+	token, err := middleware.CreateToken(admin.ID, admin.Username, constants.SubjectAdmin, admin.Role)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": constants.ErrTokenCreationFailed,
+			"error":   err.Error(),
+		})
+	}
+
+	refreshToken, err := h.Auth.IssueRefreshToken(constants.SubjectAdmin, admin.ID, "")
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
 			"message": constants.ErrTokenCreationFailed,
@@ -81,10 +216,11 @@ func LoginAdminController(c echo.Context) error {
 	}
 
 	AdminResponse := entity.AdminResponse{
-		ID:       admin.ID,
-		Username: admin.Username,
-		Email:    admin.Email,
-		Token:    token,
+		ID:           admin.ID,
+		Username:     admin.Username,
+		Email:        admin.Email,
+		Token:        token,
+		RefreshToken: refreshToken,
 	}
 
 	return c.JSON(http.StatusOK, map[string]interface{}{
@@ -94,25 +230,15 @@ func LoginAdminController(c echo.Context) error {
 }
 
 // Fungsi GetAdminByID digunakan untuk mengambil data admin berdasarkan ID.
-func GetAdminByID(c echo.Context) error {
-	AdminID, Username := middleware.ExtractToken(c)
-	Admin := entity.Admin{}
-	err := config.DB.Where("Username = ? AND ID = ?", Username, AdminID).First(&Admin).Error
-	if err != nil {
-		return c.JSON(http.StatusUnauthorized, map[string]interface{}{
-			"message": constants.ErrFailedToLogIn,
-			"error":   err.Error(),
-		})
-	}
-
+func (h *AdminHandler) GetAdminByID(c echo.Context) error {
 	ID, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, "ID Admin tidak valid")
 	}
 
 	// Mencari admin dalam basis data berdasarkan ID
-	var admin entity.Admin
-	if err := config.DB.First(&admin, ID).Error; err != nil {
+	admin, err := h.Admins.FindByID(uint(ID))
+	if err != nil {
 		return c.JSON(http.StatusNotFound, "Admin tidak ditemukan")
 	}
 
@@ -124,45 +250,56 @@ func GetAdminByID(c echo.Context) error {
 }
 
 // Fungsi UpdateAdminController digunakan untuk mengupdate data admin berdasarkan ID.
-func UpdateAdminController(c echo.Context) error {
-	AdminID, Username := middleware.ExtractToken(c)
-	Admin := entity.Admin{}
-	err := config.DB.Where("Username = ? AND ID = ?", Username, AdminID).First(&Admin).Error
-	if err != nil {
-		return c.JSON(http.StatusUnauthorized, map[string]interface{}{
-			"message": constants.ErrFailedToLogIn,
-			"error":   err.Error(),
-		})
-	}
-
+// Admin hanya dapat mengubah akunnya sendiri.
+func (h *AdminHandler) UpdateAdminController(c echo.Context) error {
 	IdStr := c.Param("id")
 	Id, err := strconv.Atoi(IdStr)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
 	}
+	principal := middleware.GetPrincipal(c)
+	if principal == nil || !principal.IsAdmin() || principal.ID != uint(Id) {
+		return middleware.Forbidden(c)
+	}
 
 	// Membuat instance baru dari entitas admin dan mengikat data dari permintaan HTTP
-	admin := new(entity.Admin)
-	if err := c.Bind(admin); err != nil {
+	request := new(adminRequest)
+	if err := c.Bind(request); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
+	admin := &request.Admin
 
 	// Mencari admin yang ada dalam basis data berdasarkan ID
-	var existingAdmin entity.Admin
-	if err := config.DB.First(&existingAdmin, Id).Error; err != nil {
+	existingAdmin, err := h.Admins.FindByID(uint(Id))
+	if err != nil {
 		return echo.NewHTTPError(http.StatusNotFound, "Admin not found")
 	}
 
 	// Memperbarui data admin yang ada dengan data baru dari permintaan
 	existingAdmin.Username = admin.Username
 	existingAdmin.Email = admin.Email
-	existingAdmin.Password = admin.Password
+	passwordChanged := request.Password != ""
+	if passwordChanged {
+		hashedPassword, err := helpers.HashPassword(request.Password)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+		existingAdmin.Password = hashedPassword
+		existingAdmin.PasswordResetRequired = false
+	}
 
 	// Menyimpan perubahan data admin ke dalam basis data
-	if err := config.DB.Save(&existingAdmin).Error; err != nil {
+	if err := h.Admins.Save(existingAdmin); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
+	// Password baru membatalkan semua sesi yang sudah diterbitkan
+	if passwordChanged {
+		if err := h.Auth.RevokeSubjectSessions(constants.SubjectAdmin, existingAdmin.ID); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+	}
+
 	// Mengirim respons HTTP berhasil setelah admin diperbarui
 	return c.JSON(http.StatusOK, map[string]interface{}{
 		"message": "success update admin",
@@ -170,20 +307,64 @@ func UpdateAdminController(c echo.Context) error {
 	})
 }
 
-// semua data internships admin
+type unlockAccountRequest struct {
+	AccountType string `json:"account_type" form:"account_type"`
+	Username    string `json:"username" form:"username"`
+	IP          string `json:"ip" form:"ip"`
+}
 
-// Membuat lowongan magang baru
-func CreateInternshipListing(c echo.Context) error {
-	AdminID, Username := middleware.ExtractToken(c)
-	Admin := entity.Admin{}
-	err := config.DB.Where("Username = ? AND ID = ?", Username, AdminID).First(&Admin).Error
-	if err != nil {
-		return c.JSON(http.StatusUnauthorized, map[string]interface{}{
-			"message": constants.ErrFailedToLogIn,
+// Fungsi UnlockAccountController digunakan admin untuk membuka kunci login akibat percobaan gagal berulang.
+func (h *AdminHandler) UnlockAccountController(c echo.Context) error {
+	request := unlockAccountRequest{}
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "Fail to parse request body",
 			"error":   err.Error(),
 		})
 	}
 
+	var identifiers []string
+	if request.Username != "" {
+		if request.AccountType != constants.SubjectUser && request.AccountType != constants.SubjectAdmin {
+			return c.JSON(http.StatusBadRequest, map[string]interface{}{
+				"message": "account_type harus 'user' atau 'admin'",
+			})
+		}
+		identifiers = append(identifiers, helpers.LoginThrottleKey(request.AccountType, request.Username))
+	}
+	if request.IP != "" {
+		identifiers = append(identifiers, "ip:"+request.IP)
+	}
+	if len(identifiers) == 0 {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "Username atau IP wajib diisi",
+		})
+	}
+
+	unlocked := []string{}
+	for _, identifier := range identifiers {
+		ok, err := h.Throttle.Unlock(identifier)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+				"message": "Gagal membuka kunci akun",
+				"error":   err.Error(),
+			})
+		}
+		if ok {
+			unlocked = append(unlocked, identifier)
+		}
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":  "Kunci login berhasil dibuka",
+		"unlocked": unlocked,
+	})
+}
+
+// semua data internships admin
+
+// Membuat lowongan magang baru
+func (h *AdminHandler) CreateInternshipListing(c echo.Context) error {
 	// Bind data lowongan dari request body
 	listing := entity.Internship_Listing{}
 	if err := c.Bind(&listing); err != nil {
@@ -193,14 +374,20 @@ func CreateInternshipListing(c echo.Context) error {
 		})
 	}
 
-	// Lakukan validasi kuota
-	if listing.Quota <= 0 {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"message": "Kuota harus lebih dari 0",
-		})
-	}
-
-	if err := config.DB.Create(&listing).Error; err != nil {
+	// Kuota dan kriteria penyaringan divalidasi oleh usecase sebelum lowongan disimpan
+	if err := h.Listings.CreateListing(&listing); err != nil {
+		var validationErr *listingusecase.ValidationError
+		switch {
+		case errors.As(err, &validationErr):
+			return c.JSON(http.StatusBadRequest, map[string]interface{}{
+				"message":     validationErr.Error(),
+				"invalidData": validationErr.Fields,
+			})
+		case errors.Is(err, listingusecase.ErrInvalidQuota), errors.Is(err, listingusecase.ErrInvalidSeats):
+			return c.JSON(http.StatusBadRequest, map[string]interface{}{
+				"message": err.Error(),
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
 			"message": "Gagal membuat lowongan magang",
 			"error":   err.Error(),
@@ -208,8 +395,8 @@ func CreateInternshipListing(c echo.Context) error {
 	}
 
 	// Cetak daftar lowongan magang setelah pembuatan
-	var internshipListings []entity.Internship_Listing
-	if err := config.DB.Find(&internshipListings).Error; err != nil {
+	internshipListings, err := h.Listings.GetListings()
+	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
 			"message": "Gagal mengambil daftar magang",
 			"error":   err.Error(),
@@ -225,19 +412,14 @@ func CreateInternshipListing(c echo.Context) error {
 }
 
 // Memperbarui lowongan magang berdasarkan ID
-func UpdateInternshipListingByID(c echo.Context) error {
-	AdminID, Username := middleware.ExtractToken(c)
-	Admin := entity.Admin{}
-	err := config.DB.Where("Username = ? AND ID = ?", Username, AdminID).First(&Admin).Error
+func (h *AdminHandler) UpdateInternshipListingByID(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, map[string]interface{}{
-			"message": constants.ErrFailedToLogIn,
-			"error":   err.Error(),
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "ID lowongan magang tidak valid",
 		})
 	}
 
-	id := c.Param("id")
-
 	// Bind data lowongan dari request body
 	listing := entity.Internship_Listing{}
 	if err := c.Bind(&listing); err != nil {
@@ -247,7 +429,24 @@ func UpdateInternshipListingByID(c echo.Context) error {
 		})
 	}
 
-	if err := config.DB.Model(&entity.Internship_Listing{}).Where("id = ?", id).Updates(&listing).Error; err != nil {
+	updatedListing, err := h.Listings.UpdateListing(uint(id), &listing)
+	if err != nil {
+		var validationErr *listingusecase.ValidationError
+		switch {
+		case errors.As(err, &validationErr):
+			return c.JSON(http.StatusBadRequest, map[string]interface{}{
+				"message":     validationErr.Error(),
+				"invalidData": validationErr.Fields,
+			})
+		case errors.Is(err, listingusecase.ErrInvalidQuota), errors.Is(err, listingusecase.ErrInvalidSeats):
+			return c.JSON(http.StatusBadRequest, map[string]interface{}{
+				"message": err.Error(),
+			})
+		case errors.Is(err, listingusecase.ErrListingNotFound):
+			return c.JSON(http.StatusNotFound, map[string]interface{}{
+				"message": err.Error(),
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
 			"message": "Gagal memperbarui lowongan magang",
 			"error":   err.Error(),
@@ -255,13 +454,6 @@ func UpdateInternshipListingByID(c echo.Context) error {
 	}
 
 	// Cetak data lowongan magang setelah pembaruan
-	var updatedListing entity.Internship_Listing
-	if err := config.DB.Where("id = ?", id).First(&updatedListing).Error; err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
-			"message": "Gagal mengambil data lowongan magang yang diperbarui",
-			"error":   err.Error(),
-		})
-	}
 
 	fmt.Printf("Data Lowongan yang Diperbarui: %+v\n", updatedListing)
 
@@ -271,21 +463,72 @@ func UpdateInternshipListingByID(c echo.Context) error {
 	})
 }
 
-// Menghapus lowongan magang berdasarkan ID
-func DeleteInternshipListingByID(c echo.Context) error {
-	AdminID, Username := middleware.ExtractToken(c)
-	Admin := entity.Admin{}
-	err := config.DB.Where("Username = ? AND ID = ?", Username, AdminID).First(&Admin).Error
+// ChangeInternshipListingStatus memindahkan lowongan magang ke status draft, published, closed,
+// atau archived sesuai siklus hidup lowongan.
+func (h *AdminHandler) ChangeInternshipListingStatus(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, map[string]interface{}{
-			"message": constants.ErrFailedToLogIn,
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "ID lowongan magang tidak valid",
+		})
+	}
+
+	request := struct {
+		Status string `json:"status" form:"status"`
+	}{}
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "Gagal mem-parsing request body",
+			"error":   err.Error(),
+		})
+	}
+	if !liststatus.IsValid(request.Status) {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message":     "Data perubahan status tidak valid",
+			"invalidData": map[string]string{"status": "Status tidak dikenal"},
+		})
+	}
+
+	listing, err := h.Listings.ChangeStatus(uint(id), request.Status)
+	if err != nil {
+		var validationErr *listingusecase.ValidationError
+		switch {
+		case errors.As(err, &validationErr):
+			return c.JSON(http.StatusBadRequest, map[string]interface{}{
+				"message":     validationErr.Error(),
+				"invalidData": validationErr.Fields,
+			})
+		case errors.Is(err, listingusecase.ErrListingNotFound):
+			return c.JSON(http.StatusNotFound, map[string]interface{}{
+				"message": err.Error(),
+			})
+		case errors.Is(err, listingusecase.ErrInvalidTransition), errors.Is(err, listingusecase.ErrStatusChanged):
+			return c.JSON(http.StatusConflict, map[string]interface{}{
+				"message": err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": "Gagal mengubah status lowongan magang",
 			"error":   err.Error(),
 		})
 	}
 
-	id := c.Param("id")
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Status lowongan magang berhasil diubah",
+		"listing": listing,
+	})
+}
+
+// Menghapus lowongan magang berdasarkan ID
+func (h *AdminHandler) DeleteInternshipListingByID(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "ID lowongan magang tidak valid",
+		})
+	}
 
-	if err := config.DB.Where("id = ?", id).Delete(&entity.Internship_Listing{}).Error; err != nil {
+	if err := h.Listings.DeleteListing(uint(id)); err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
 			"message": "Gagal menghapus pendaftaran magang",
 			"error":   err.Error(),
@@ -297,59 +540,53 @@ func DeleteInternshipListingByID(c echo.Context) error {
 	})
 }
 
-// Fungsi ini digunakan untuk memilih kandidat berdasarkan ID dan rentang nilai IPK (GPA)
-func SelectCandidatesByGPAID(c echo.Context) error {
-	AdminID, Username := middleware.ExtractToken(c)
-	Admin := entity.Admin{}
-	err := config.DB.Where("Username = ? AND ID = ?", Username, AdminID).First(&Admin).Error
+// ScreenCandidateByID menyaring kandidat berdasarkan kriteria lowongannya lalu menerima atau
+// menolaknya. Alasan lolos/gagal setiap aturan dikembalikan agar penolakan dapat dijelaskan.
+func (h *AdminHandler) ScreenCandidateByID(c echo.Context) error {
+	candidateID, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, map[string]interface{}{
-			"message": constants.ErrFailedToLogIn,
-			"error":   err.Error(),
-		})
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
 	}
-	candidateID := c.Param("id")
-	// Tetapkan nilai minimum dan maksimum IPK yang diinginkan
-	minGPA := 3.5
-	maxGPA := 4.0
 
-	// Menggunakan GORM untuk mengambil kandidat yang sesuai dengan ID
-	var candidate entity.Internship_ApplicationForm
-	if err := config.DB.Where("ID = ?", candidateID).First(&candidate).Error; err != nil {
+	candidate, result, err := h.Applications.Screen(uint(candidateID), actorOf(middleware.GetPrincipal(c)))
+	if err != nil {
+		switch {
+		case errors.Is(err, applicationusecase.ErrApplicationNotFound), errors.Is(err, applicationusecase.ErrListingNotFound):
+			return c.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+		case errors.Is(err, applicationusecase.ErrInvalidTransition), errors.Is(err, applicationusecase.ErrStatusChanged),
+			errors.Is(err, applicationusecase.ErrSeatsFull):
+			return c.JSON(http.StatusConflict, map[string]interface{}{"error": err.Error(), "screening": result})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
 	}
 
-	// Tetapkan status sesuai dengan kriteria yang telah Anda tetapkan
-	if candidate.IsCanceled {
-		candidate.Status = constants.StatusCanceled
-	} else if candidate.GPA < minGPA {
-		candidate.Status = constants.StatusRejected
-	} else if candidate.GPA >= minGPA && candidate.GPA <= maxGPA {
-		candidate.Status = constants.StatusAccepted
-	} else {
-		// Jika tidak memenuhi syarat, tetapkan status "Reject"
-		candidate.Status = constants.StatusRejected
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":   "Candidate screened against listing criteria",
+		"status":    candidate.Status,
+		"screening": result,
+	})
+}
+
+// PreviewScreeningByID menampilkan hasil penyaringan kandidat tanpa mengubah statusnya.
+func (h *AdminHandler) PreviewScreeningByID(c echo.Context) error {
+	candidateID, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{"error": err.Error()})
 	}
 
-	if err := config.DB.Save(&candidate).Error; err != nil {
+	result, err := h.Applications.PreviewScreening(uint(candidateID))
+	if err != nil {
+		if errors.Is(err, applicationusecase.ErrApplicationNotFound) || errors.Is(err, applicationusecase.ErrListingNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]interface{}{"error": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
 	}
 
-	return c.JSON(http.StatusOK, map[string]interface{}{"message": "Candidate selected based on GPA range"})
+	return c.JSON(http.StatusOK, map[string]interface{}{"screening": result})
 }
 
 // Fungsi ini digunakan untuk mengirim email kepada kandidat yang diterima (status "accepted").
-func SendEmailHandler(c echo.Context) error {
-	AdminID, Username := middleware.ExtractToken(c)
-	Admin := entity.Admin{}
-	err := config.DB.Where("Username = ? AND ID = ?", Username, AdminID).First(&Admin).Error
-	if err != nil {
-		return c.JSON(http.StatusUnauthorized, map[string]interface{}{
-			"message": constants.ErrFailedToLogIn,
-			"error":   err.Error(),
-		})
-	}
-
+func (h *AdminHandler) SendEmailHandler(c echo.Context) error {
 	userEmail := c.FormValue("userEmail")
 	username := c.FormValue("username")
 	status := c.FormValue("status")
@@ -359,7 +596,7 @@ func SendEmailHandler(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Email can only be sent for accepted candidates"})
 	}
 
-	err = helpers.SendEmailToUser(userEmail, username, status)
+	err := helpers.SendEmailToUser(userEmail, username, status)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to send email", "details": err.Error()})
 	}
@@ -369,10 +606,10 @@ func SendEmailHandler(c echo.Context) error {
 }
 
 // Fungsi ini digunakan untuk menampilkan semua kandidat yang ada di database.
-func ViewAllCandidates(c echo.Context) error {
+func (h *AdminHandler) ViewAllCandidates(c echo.Context) error {
 	// Mengambil semua kandidat dari database
-	var candidates []entity.Internship_ApplicationForm
-	if err := config.DB.Find(&candidates).Error; err != nil {
+	candidates, err := h.Applications.GetApplications()
+	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]interface{}{"error": err.Error()})
 	}
 
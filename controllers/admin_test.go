@@ -3,27 +3,44 @@ package controllers
 import (
 	"bytes"
 	"encoding/json"
-	"io/ioutil"
+	applicationusecase "miniproject/applications/usecase"
+	"miniproject/constants"
 	"miniproject/entity"
+	"miniproject/helpers"
 	"miniproject/middleware"
+	"miniproject/repository"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 )
 
+func seedAdmin(t *testing.T, repos *repository.Repositories, username, email, password string) *entity.Admin {
+	hashedPassword, err := helpers.HashPassword(password)
+	assert.NoError(t, err)
+	admin := &entity.Admin{Username: username, Email: email, Password: hashedPassword, Role: constants.RoleAdmin}
+	assert.NoError(t, repos.Admins.Create(admin))
+	return admin
+}
+
+func adminPrincipal(admin *entity.Admin) *middleware.Principal {
+	return &middleware.Principal{ID: admin.ID, Username: admin.Username, Type: constants.SubjectAdmin, Role: constants.RoleAdmin, Admin: admin}
+}
+
 func TestRegisterAdmin(t *testing.T) {
+	_, _, h := newTestHandlers()
 	e := echo.New()
-	req := httptest.NewRequest(http.MethodPost, "/register", nil)
-	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
 
 	// Membuat objek Admin untuk pengujian
-	admin := entity.Admin{
-		Email: "caca@gmail.com",
+	admin := adminRequest{
+		Admin:    entity.Admin{Username: "caca", Email: "caca@gmail.com"},
+		Password: "caca12",
 	}
 
 	// Marshal objek Admin menjadi JSON
@@ -32,11 +49,13 @@ func TestRegisterAdmin(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	// Menggunakan ioutil.NopCloser untuk membungkus JSON sebagai ReadCloser
-	req.Body = ioutil.NopCloser(bytes.NewReader(adminJSON))
+	req := httptest.NewRequest(http.MethodPost, "/register", bytes.NewReader(adminJSON))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
 
 	// Memanggil fungsi RegisterAdmin
-	err = RegisterAdmin(c)
+	err = h.RegisterAdmin(c)
 
 	// Memeriksa respons HTTP
 	if assert.NoError(t, err) {
@@ -48,62 +67,159 @@ func TestRegisterAdmin(t *testing.T) {
 	err = json.Unmarshal(rec.Body.Bytes(), &response)
 	if assert.NoError(t, err) {
 		assert.Equal(t, "Success create new user", response["message"])
+		// Hash password tidak boleh ikut terkirim di respons
+		assert.NotContains(t, rec.Body.String(), "password\"")
+		assert.NotContains(t, rec.Body.String(), "$2a$")
 	}
 }
 
-func TestLoginAdminController(t *testing.T) {
+func TestRegisterAdminConcurrentBootstrap(t *testing.T) {
+	repos, _, h := newTestHandlers()
+	e := echo.New()
+
+	// Request bootstrap paralel hanya boleh menghasilkan satu admin
+	codes := make(chan int, 5)
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			payload := `{"username": "admin` + strconv.Itoa(i) + `", "email": "admin` + strconv.Itoa(i) + `@gmail.com", "password": "rahasia"}`
+			req := httptest.NewRequest(http.MethodPost, "/register", strings.NewReader(payload))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			assert.NoError(t, h.RegisterAdmin(e.NewContext(req, rec)))
+			codes <- rec.Code
+		}(i)
+	}
+	wg.Wait()
+	close(codes)
+
+	created := 0
+	for code := range codes {
+		if code == http.StatusOK {
+			created++
+		} else {
+			assert.Equal(t, http.StatusForbidden, code)
+		}
+	}
+	assert.Equal(t, 1, created)
+	count, err := repos.Admins.Count()
+	assert.NoError(t, err)
+	assert.EqualValues(t, 1, count)
+}
+
+func TestRegisterAdminInviteOnly(t *testing.T) {
+	repos, _, h := newTestHandlers()
+	seedAdmin(t, repos, "caca", "caca@gmail.com", "caca12")
 	e := echo.New()
-	req := httptest.NewRequest(http.MethodPost, "/login", nil)
+	payload := `{"username": "baru", "email": "baru@gmail.com", "password": "baru12"}`
+	req := httptest.NewRequest(http.MethodPost, "/register", strings.NewReader(payload))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
 
-	// Membuat data admin palsu untuk digunakan dalam pengujian
-	fakeAdmin := entity.Admin{
-		Username: "caca",
+	// Setelah ada admin, registrasi terbuka ditutup dan harus melalui undangan
+	if assert.NoError(t, h.RegisterAdmin(c)) {
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Contains(t, rec.Body.String(), constants.ErrAdminInviteOnly)
+	}
+}
+
+// adminFailures mengembalikan hitungan login gagal yang tercatat untuk username admin.
+func adminFailures(t *testing.T, h *AdminHandler, username string) int {
+	throttles, err := h.Throttle.Store.FindByIdentifiers([]string{helpers.LoginThrottleKey(constants.SubjectAdmin, username)})
+	assert.NoError(t, err)
+	if len(throttles) == 0 {
+		return 0
+	}
+	return throttles[0].Failures
+}
+
+func TestLoginAdminTwoFactorKeepsFailures(t *testing.T) {
+	repos, _, h := newTestHandlers()
+	admin := seedAdmin(t, repos, "caca", "caca@gmail.com", "caca12")
+	admin.TOTPEnabled = true
+	assert.NoError(t, repos.Admins.Save(admin))
+	keys := helpers.LoginThrottleKeys(constants.SubjectAdmin, "caca", "192.0.2.1")
+	assert.NoError(t, h.Throttle.RecordFailure(keys))
+	assert.NoError(t, h.Throttle.RecordFailure(keys))
+
+	e := echo.New()
+	login := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(`{"username": "caca", "password": "caca12"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		assert.NoError(t, h.LoginAdminController(e.NewContext(req, rec)))
+		return rec
+	}
+
+	// Password benar belum menyelesaikan login sehingga percobaan kode 2FA tetap dihitung
+	rec := login()
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "challenge_token")
+	assert.Equal(t, 2, adminFailures(t, h, "caca"))
+
+	admin.TOTPEnabled = false
+	assert.NoError(t, repos.Admins.Save(admin))
+	rec = login()
+	assert.Contains(t, rec.Body.String(), "refresh_token")
+	assert.Equal(t, 0, adminFailures(t, h, "caca"))
+}
+
+func TestLoginAdminController(t *testing.T) {
+	repos, _, h := newTestHandlers()
+	seedAdmin(t, repos, "caca", "caca@gmail.com", "caca12")
+	e := echo.New()
+
+	// Membuat data login admin untuk digunakan dalam pengujian
+	fakeAdmin := adminRequest{
+		Admin:    entity.Admin{Username: "caca"},
 		Password: "caca12",
-		Email:    "caca@gmail.com",
 	}
 
-	// Menambahkan data palsu ke body request
+	// Menambahkan data login ke body request
 	body, _ := json.Marshal(fakeAdmin)
-	req.Body = ioutil.NopCloser(bytes.NewReader(body)) 
+	req := httptest.NewRequest(http.MethodPost, "/login", bytes.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
 
 	// Memanggil fungsi LoginAdminController
-	if assert.NoError(t, LoginAdminController(c)) {
+	if assert.NoError(t, h.LoginAdminController(c)) {
 		assert.Equal(t, http.StatusOK, rec.Code)
 
 		// Memeriksa isi respon JSON
-		expectedResponse := map[string]interface{}{
-			"message": "Success login",
-			"user": map[string]interface{}{
-				"ID":       1, 
-				"Username": "caca",
-				"Email":    "caca@gmail.com",
-				"Token":    "your_token", 
-			},
-		}
 		var response map[string]interface{}
 		err := json.Unmarshal(rec.Body.Bytes(), &response)
 		if assert.NoError(t, err) {
-			assert.Equal(t, expectedResponse, response)
+			assert.Equal(t, "Success login", response["message"])
+			user, ok := response["user"].(map[string]interface{})
+			if assert.True(t, ok) {
+				assert.Equal(t, "caca", user["username"])
+				assert.Equal(t, "caca@gmail.com", user["email"])
+				assert.NotEmpty(t, user["token"])
+				assert.NotEmpty(t, user["refresh_token"])
+			}
 		}
 	}
 }
 
 func TestGetAdminByID(t *testing.T) {
+	repos, _, h := newTestHandlers()
+	admin := seedAdmin(t, repos, "admin_username", "admin_email", "caca12")
+
 	// Membuat instansi Echo dan request palsu
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/admin/1", nil)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
-
-	// Menambahkan token palsu ke konteks untuk simulasi
-	fakeToken, err := middleware.CreateToken(1, "testadmin") 
-	assert.NoError(t, err)                                  
-	c.Set("user", fakeToken)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	middleware.SetPrincipal(c, adminPrincipal(admin))
 
 	// Memanggil fungsi GetAdminByID
-	if assert.NoError(t, GetAdminByID(c)) {
+	if assert.NoError(t, h.GetAdminByID(c)) {
 		assert.Equal(t, http.StatusOK, rec.Code)
 
 		// Memeriksa isi respon JSON
@@ -113,31 +229,30 @@ func TestGetAdminByID(t *testing.T) {
 			assert.Equal(t, "Success", response["message"])
 			admin, ok := response["admin"].(map[string]interface{})
 			if assert.True(t, ok) {
-				assert.Equal(t, "admin_username", admin["Username"]) 
-				assert.Equal(t, "admin_email", admin["Email"])      
+				assert.Equal(t, "admin_username", admin["username"])
+				assert.Equal(t, "admin_email", admin["email"])
 			}
 		}
 	}
 }
 
 func TestGetAdminByIDInvalidID(t *testing.T) {
+	_, _, h := newTestHandlers()
+
 	// Membuat instansi Echo dan request palsu dengan ID yang tidak valid
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/admin/invalid_id", nil)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
-
-	// Menambahkan token palsu ke konteks untuk simulasi
-	fakeToken, err := middleware.CreateToken(1, "testadmin") 
-	assert.NoError(t, err)                                   
-	c.Set("user", fakeToken)
+	c.SetParamNames("id")
+	c.SetParamValues("invalid_id")
 
 	// Memanggil fungsi GetAdminByID dengan ID yang tidak valid
-	if assert.NoError(t, GetAdminByID(c)) {
+	if assert.NoError(t, h.GetAdminByID(c)) {
 		assert.Equal(t, http.StatusBadRequest, rec.Code)
 
 		// Memeriksa isi respon JSON
-		var response map[string]interface{}
+		var response string
 		err := json.Unmarshal(rec.Body.Bytes(), &response)
 		if assert.NoError(t, err) {
 			assert.Equal(t, "ID Admin tidak valid", response)
@@ -146,23 +261,22 @@ func TestGetAdminByIDInvalidID(t *testing.T) {
 }
 
 func TestGetAdminByIDNotFound(t *testing.T) {
+	_, _, h := newTestHandlers()
+
 	// Membuat instansi Echo dan request palsu dengan ID yang tidak ada dalam basis data
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/admin/999", nil)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
-
-	// Menambahkan token palsu ke konteks untuk simulasi
-	fakeToken, err := middleware.CreateToken(1, "testadmin") 
-	assert.NoError(t, err)                                   
-	c.Set("user", fakeToken)
+	c.SetParamNames("id")
+	c.SetParamValues("999")
 
 	// Memanggil fungsi GetAdminByID dengan ID yang tidak ditemukan
-	if assert.NoError(t, GetAdminByID(c)) {
+	if assert.NoError(t, h.GetAdminByID(c)) {
 		assert.Equal(t, http.StatusNotFound, rec.Code)
 
 		// Memeriksa isi respon JSON
-		var response map[string]interface{}
+		var response string
 		err := json.Unmarshal(rec.Body.Bytes(), &response)
 		if assert.NoError(t, err) {
 			assert.Equal(t, "Admin tidak ditemukan", response)
@@ -171,6 +285,8 @@ func TestGetAdminByIDNotFound(t *testing.T) {
 }
 
 func TestUpdateAdminController(t *testing.T) {
+	repos, _, h := newTestHandlers()
+	admin := seedAdmin(t, repos, "caca", "caca@gmail.com", "caca12")
 	e := echo.New()
 
 	// Membuat permintaan HTTP palsu untuk tes
@@ -179,9 +295,12 @@ func TestUpdateAdminController(t *testing.T) {
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	middleware.SetPrincipal(c, adminPrincipal(admin))
 
 	// Memanggil fungsi UpdateAdminController
-	if err := UpdateAdminController(c); err != nil {
+	if err := h.UpdateAdminController(c); err != nil {
 		t.Fatalf("Expected no error, but got: %v", err)
 	}
 
@@ -191,13 +310,38 @@ func TestUpdateAdminController(t *testing.T) {
 	}
 
 	// Anda juga dapat memeriksa respons JSON yang dihasilkan
-	expectedResponse := `{"message": "success update admin"`
+	expectedResponse := `"message":"success update admin"`
 	if !strings.Contains(rec.Body.String(), expectedResponse) {
 		t.Fatalf("Expected response to contain: %s", expectedResponse)
 	}
 }
 
+func TestUpdateAdminControllerOtherAdmin(t *testing.T) {
+	repos, _, h := newTestHandlers()
+	admin := seedAdmin(t, repos, "caca", "caca@gmail.com", "caca12")
+	other := seedAdmin(t, repos, "dodi", "dodi@gmail.com", "dodi12")
+	e := echo.New()
+
+	reqBody := `{"Username":"dodo", "Email":"dodo@gmail.com", "Password":"dodo12"}`
+	req := httptest.NewRequest(http.MethodPut, "/admins/2", strings.NewReader(reqBody))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(int(other.ID)))
+	middleware.SetPrincipal(c, adminPrincipal(admin))
+
+	if assert.NoError(t, h.UpdateAdminController(c)) {
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	}
+	stored, err := repos.Admins.FindByID(other.ID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "dodi", stored.Username)
+	}
+}
+
 func TestUpdateAdminControllerInvalidID(t *testing.T) {
+	_, _, h := newTestHandlers()
 	e := echo.New()
 
 	// Membuat permintaan HTTP palsu dengan ID yang tidak valid
@@ -206,27 +350,28 @@ func TestUpdateAdminControllerInvalidID(t *testing.T) {
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("invalidID")
 
 	// Memanggil fungsi UpdateAdminController
-	if err := UpdateAdminController(c); err == nil {
+	err := h.UpdateAdminController(c)
+	if err == nil {
 		t.Fatalf("Expected an error, but got none")
 	}
 
 	// Memeriksa status code 400 Bad Request
-	if rec.Code != http.StatusBadRequest {
-		t.Fatalf("Expected status code %d, but got: %d", http.StatusBadRequest, rec.Code)
+	if err.(*echo.HTTPError).Code != http.StatusBadRequest {
+		t.Fatalf("Expected status code %d, but got: %d", http.StatusBadRequest, err.(*echo.HTTPError).Code)
 	}
 }
 
-
 func TestCreateInternshipListing(t *testing.T) {
+	_, _, h := newTestHandlers()
 	e := echo.New()
-	req := httptest.NewRequest(http.MethodPost, "/create-internship", nil)
-	rec := httptest.NewRecorder()
-	e.NewContext(req, rec)
 
 	// Test case 1: Successful Creation
 	requestBody1 := map[string]interface{}{
+		"Title": "Software Engineer",
 		"Quota": 10,
 	}
 	reqBody1, _ := json.Marshal(requestBody1)
@@ -234,46 +379,231 @@ func TestCreateInternshipListing(t *testing.T) {
 	req1.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec1 := httptest.NewRecorder()
 	c1 := e.NewContext(req1, rec1)
-	CreateInternshipListing(c1)
+	h.CreateInternshipListing(c1)
 	assert.Equal(t, http.StatusCreated, rec1.Code)
 
-	// Test case 2: Unauthorized
-	req2 := httptest.NewRequest(http.MethodPost, "/create-internship", nil)
-	rec2 := httptest.NewRecorder()
-	c2 := e.NewContext(req2, rec2)
-	CreateInternshipListing(c2)
-	assert.Equal(t, http.StatusUnauthorized, rec2.Code)
-
-	// Test case 3: Bad Request
-	requestBody3 := map[string]interface{}{
+	// Test case 2: Bad Request
+	requestBody2 := map[string]interface{}{
 		"Quota": -1,
 	}
-	reqBody3, _ := json.Marshal(requestBody3)
-	req3 := httptest.NewRequest(http.MethodPost, "/create-internship", bytes.NewReader(reqBody3))
-	req3.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
-	rec3 := httptest.NewRecorder()
-	c3 := e.NewContext(req3, rec3)
-	CreateInternshipListing(c3)
-	assert.Equal(t, http.StatusBadRequest, rec3.Code)
+	reqBody2, _ := json.Marshal(requestBody2)
+	req2 := httptest.NewRequest(http.MethodPost, "/create-internship", bytes.NewReader(reqBody2))
+	req2.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec2 := httptest.NewRecorder()
+	c2 := e.NewContext(req2, rec2)
+	h.CreateInternshipListing(c2)
+	assert.Equal(t, http.StatusBadRequest, rec2.Code)
 }
 
-
 func TestUpdateInternshipListingByID(t *testing.T) {
+	repos, _, h := newTestHandlers()
+	assert.NoError(t, repos.Listings.Create(&entity.Internship_Listing{Title: "Software Engineer", Quota: 2}))
+
 	// Inisialisasi Echo framework
 	e := echo.New()
-	req := httptest.NewRequest(http.MethodPut, "/admin/InternshipListing/:id", strings.NewReader(`{"field1": "value1", "field2": "value2"}`)) 
+	req := httptest.NewRequest(http.MethodPut, "/admin/internship/1", strings.NewReader(`{"title": "Backend Engineer"}`))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
 
-	// Simulasikan token dan data Admin yang valid
-	token := "your-valid-token"
-	username := "caca"
-	c.Set("user", map[string]interface{}{"token": token, "username": username})
-	err := UpdateInternshipListingByID(c)
+	err := h.UpdateInternshipListingByID(c)
 
 	// Lakukan pengujian
 	if assert.NoError(t, err) {
 		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Backend Engineer")
+	}
+
+	// Field yang tidak dikirim tidak ikut berubah
+	listing, err := repos.Listings.FindByID(1)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 2, listing.Quota)
+	}
+}
+
+func TestChangeInternshipListingStatus(t *testing.T) {
+	repos, _, h := newTestHandlers()
+	listing := &entity.Internship_Listing{Title: "Software Engineer", Quota: 2}
+	assert.NoError(t, repos.Listings.Create(listing))
+
+	changeStatus := func(payload string) *httptest.ResponseRecorder {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPut, "/admin/internship/:id/status", strings.NewReader(payload))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(strconv.Itoa(int(listing.ID)))
+		assert.NoError(t, h.ChangeInternshipListingStatus(c))
+		return rec
+	}
+
+	rec := changeStatus(`{"status": "open"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = changeStatus(`{"status": "closed"}`)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	rec = changeStatus(`{"status": "published"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"status":"published"`)
+}
+
+func TestCreateAdminInvitationInvalidRole(t *testing.T) {
+	repos, _, h := newTestHandlers()
+	admin := seedAdmin(t, repos, "caca", "caca@gmail.com", "caca12")
+	e := echo.New()
+	payload := `{"email": "baru@gmail.com", "role": "user"}`
+	req := httptest.NewRequest(http.MethodPost, "/admin/invitations", strings.NewReader(payload))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	middleware.SetPrincipal(c, adminPrincipal(admin))
+
+	if assert.NoError(t, h.CreateAdminInvitationController(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "role")
+	}
+}
+
+func TestAcceptAdminInvitationMissingToken(t *testing.T) {
+	_, _, h := newTestHandlers()
+	e := echo.New()
+	payload := `{"username": "baru", "password": "rahasia123"}`
+	req := httptest.NewRequest(http.MethodPost, "/admin/invitations/accept", strings.NewReader(payload))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	if assert.NoError(t, h.AcceptAdminInvitationController(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Token is required")
+	}
+}
+
+func TestAcceptAdminInvitation(t *testing.T) {
+	repos, _, h := newTestHandlers()
+	admin := seedAdmin(t, repos, "caca", "caca@gmail.com", "caca12")
+	token, err := helpers.GenerateToken()
+	assert.NoError(t, err)
+	assert.NoError(t, repos.Invitations.Create(&entity.AdminInvitation{
+		Email:       "baru@gmail.com",
+		Role:        constants.RoleAdmin,
+		TokenHash:   helpers.HashToken(token),
+		ExpiresAt:   time.Now().Add(time.Hour),
+		InvitedByID: admin.ID,
+	}, time.Now()))
+
+	accept := func() *httptest.ResponseRecorder {
+		e := echo.New()
+		payload := `{"token": "` + token + `", "username": "baru", "password": "rahasia123"}`
+		req := httptest.NewRequest(http.MethodPost, "/admin/invitations/accept", strings.NewReader(payload))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		assert.NoError(t, h.AcceptAdminInvitationController(e.NewContext(req, rec)))
+		return rec
+	}
+
+	rec := accept()
+	assert.Equal(t, http.StatusOK, rec.Code)
+	created, err := repos.Admins.FindByUsername("baru")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "baru@gmail.com", created.Email)
+	}
+
+	// Undangan hanya dapat dipakai satu kali
+	rec = accept()
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestTransitionApplicationStatus(t *testing.T) {
+	repos, _, h := newTestHandlers()
+	admin := seedAdmin(t, repos, "caca", "caca@gmail.com", "caca12")
+	user := seedUser(t, repos, "raras", "raras@gmail.com", "raras12", true)
+	application := seedApplication(t, repos, user)
+
+	transition := func(payload string) *httptest.ResponseRecorder {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPut, "/admin/applications/:id/status", strings.NewReader(payload))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(strconv.Itoa(int(application.ID)))
+		middleware.SetPrincipal(c, adminPrincipal(admin))
+		assert.NoError(t, h.TransitionApplicationStatusController(c))
+		return rec
+	}
+
+	rec := transition(`{"status": "verified"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "reason")
+
+	rec = transition(`{"status": "accepted", "reason": "IPK tinggi"}`)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	rec = transition(`{"status": "verified", "reason": "Dokumen lengkap"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	history, err := repos.Applications.FindStatusHistory(application.ID)
+	if assert.NoError(t, err) && assert.Len(t, history, 1) {
+		assert.Equal(t, constants.StatusVerified, history[0].ToStatus)
+		assert.Equal(t, admin.ID, history[0].ChangedByID)
+		assert.Equal(t, "Dokumen lengkap", history[0].Reason)
+	}
+}
+
+func TestSelectionRunDryRun(t *testing.T) {
+	repos, _, h := newTestHandlers()
+	admin := seedAdmin(t, repos, "caca", "caca@gmail.com", "caca12")
+	listing := &entity.Internship_Listing{Title: "Software Engineer", Quota: 5, Seats: 1}
+	assert.NoError(t, repos.Listings.Create(listing))
+	application := &entity.Internship_ApplicationForm{GPA: 3.8, Status: constants.StatusVerified, InternshipListingID: listing.ID}
+	assert.NoError(t, repos.Applications.Create(application))
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/admin/internship/:id/selection-run", strings.NewReader(`{"dry_run": true}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(int(listing.ID)))
+	middleware.SetPrincipal(c, adminPrincipal(admin))
+
+	if assert.NoError(t, h.SelectionRunController(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"accepted":1`)
+	}
+
+	stored, err := repos.Applications.FindByID(application.ID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, constants.StatusVerified, stored.Status)
+	}
+}
+
+func TestGetListingOffersController(t *testing.T) {
+	repos, _, h := newTestHandlers()
+	admin := seedAdmin(t, repos, "caca", "caca@gmail.com", "caca12")
+	listing := &entity.Internship_Listing{Title: "Software Engineer", Quota: 5, Seats: 1}
+	assert.NoError(t, repos.Listings.Create(listing))
+	application := &entity.Internship_ApplicationForm{UserID: 9, GPA: 3.8, Status: constants.StatusVerified, InternshipListingID: listing.ID}
+	assert.NoError(t, repos.Applications.Create(application))
+	_, err := h.Applications.RunSelection(listing.ID, applicationusecase.SelectionOptions{}, actorOf(adminPrincipal(admin)))
+	assert.NoError(t, err)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/admin/internship/:id/offers", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(int(listing.ID)))
+	middleware.SetPrincipal(c, adminPrincipal(admin))
+
+	if assert.NoError(t, h.GetListingOffersController(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"status":"pending"`)
+		assert.Contains(t, rec.Body.String(), `"user_id":9`)
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"errors"
+	"miniproject/applications/status"
+	applicationusecase "miniproject/applications/usecase"
+	"miniproject/middleware"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+type transitionRequest struct {
+	Status string `json:"status" form:"status"`
+	Reason string `json:"reason" form:"reason"`
+}
+
+// actorOf membentuk pelaku perubahan status dari principal yang sedang login.
+func actorOf(principal *middleware.Principal) applicationusecase.Actor {
+	if principal == nil {
+		return applicationusecase.Actor{}
+	}
+	return applicationusecase.Actor{Type: principal.Type, ID: principal.ID}
+}
+
+// TransitionApplicationStatusController memindahkan formulir ke status lain sesuai state machine
+// dan mencatat admin pelaku beserta alasannya pada riwayat status.
+func (h *AdminHandler) TransitionApplicationStatusController(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "ID tidak valid",
+		})
+	}
+
+	request := transitionRequest{}
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "Fail to parse request body",
+			"error":   err.Error(),
+		})
+	}
+	request.Reason = strings.TrimSpace(request.Reason)
+
+	invalidData := make(map[string]string)
+	if !status.IsValid(request.Status) {
+		invalidData["status"] = "Status tidak dikenal"
+	}
+	if request.Reason == "" {
+		invalidData["reason"] = "Alasan perubahan status wajib diisi"
+	}
+	if len(invalidData) > 0 {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message":     "Data perubahan status tidak valid",
+			"invalidData": invalidData,
+		})
+	}
+
+	application, err := h.Applications.Transition(uint(id), request.Status, actorOf(middleware.GetPrincipal(c)), request.Reason)
+	if err != nil {
+		switch {
+		case errors.Is(err, applicationusecase.ErrApplicationNotFound):
+			return c.JSON(http.StatusNotFound, map[string]interface{}{
+				"message": err.Error(),
+			})
+		case errors.Is(err, applicationusecase.ErrInvalidTransition), errors.Is(err, applicationusecase.ErrStatusChanged),
+			errors.Is(err, applicationusecase.ErrSeatsFull):
+			return c.JSON(http.StatusConflict, map[string]interface{}{
+				"message": err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": "Gagal mengubah status formulir aplikasi",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":     "Status formulir aplikasi berhasil diubah",
+		"application": application,
+	})
+}
+
+// GetApplicationStatusHistoryController menampilkan riwayat perubahan status sebuah formulir.
+func (h *AdminHandler) GetApplicationStatusHistoryController(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "ID tidak valid",
+		})
+	}
+
+	history, err := h.Applications.GetStatusHistory(uint(id))
+	if err != nil {
+		if errors.Is(err, applicationusecase.ErrApplicationNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]interface{}{
+				"message": err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": "Gagal mengambil riwayat status formulir aplikasi",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Riwayat status formulir aplikasi",
+		"history": history,
+	})
+}
@@ -0,0 +1,148 @@
+package controllers
+
+import (
+	"errors"
+	"math"
+	"miniproject/constants"
+	"miniproject/entity"
+	"miniproject/middleware"
+	"miniproject/repository"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// AuthHandler menangani rute sesi yang berlaku untuk user maupun admin.
+type AuthHandler struct {
+	Users  repository.UserRepository
+	Admins repository.AdminRepository
+	Auth   *middleware.Auth
+}
+
+func NewAuthHandler(repos *repository.Repositories, auth *middleware.Auth) *AuthHandler {
+	return &AuthHandler{
+		Users:  repos.Users,
+		Admins: repos.Admins,
+		Auth:   auth,
+	}
+}
+
+type refreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" form:"refresh_token"`
+}
+
+// tooManyLoginAttempts mengirim respons 429 beserta header Retry-After (detik).
+func tooManyLoginAttempts(c echo.Context, retryAfter time.Duration) error {
+	return tooManyRequests(c, retryAfter, constants.ErrTooManyAttempts)
+}
+
+// tooManyRequests mengirim respons 429 dengan message beserta header Retry-After (detik).
+func tooManyRequests(c echo.Context, retryAfter time.Duration, message string) error {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	c.Response().Header().Set("Retry-After", strconv.Itoa(seconds))
+	return c.JSON(http.StatusTooManyRequests, map[string]interface{}{
+		"message":     message,
+		"retry_after": seconds,
+	})
+}
+
+// loginUnavailable mengirim respons 503 jika status throttle login tidak dapat diperiksa.
+func loginUnavailable(c echo.Context, err error) error {
+	return c.JSON(http.StatusServiceUnavailable, map[string]interface{}{
+		"message": constants.ErrLoginUnavailable,
+		"error":   err.Error(),
+	})
+}
+
+// RefreshTokenController menukar refresh token dengan access token baru dan refresh token pengganti.
+func (h *AuthHandler) RefreshTokenController(c echo.Context) error {
+	request := refreshTokenRequest{}
+	if err := c.Bind(&request); err != nil || request.RefreshToken == "" {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "Refresh token wajib diisi",
+		})
+	}
+
+	current, refreshToken, err := h.Auth.RotateRefreshToken(request.RefreshToken)
+	if err != nil {
+		if errors.Is(err, middleware.ErrRefreshTokenInvalid) || errors.Is(err, middleware.ErrRefreshTokenReused) {
+			return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+				"message": err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": constants.ErrTokenCreationFailed,
+			"error":   err.Error(),
+		})
+	}
+
+	// Muat ulang subject agar username dan role di access token selalu terbaru
+	var username, role string
+	switch current.SubjectType {
+	case constants.SubjectAdmin:
+		var admin *entity.Admin
+		if admin, err = h.Admins.FindByID(current.SubjectID); err == nil {
+			username, role = admin.Username, admin.Role
+		}
+	default:
+		var user *entity.User
+		if user, err = h.Users.FindByID(current.SubjectID); err == nil {
+			username, role = user.Username, user.Role
+		}
+	}
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+			"message": middleware.ErrRefreshTokenInvalid.Error(),
+		})
+	}
+
+	token, err := middleware.CreateToken(current.SubjectID, username, current.SubjectType, role)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": constants.ErrTokenCreationFailed,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":       "Success refresh token",
+		"token":         token,
+		"refresh_token": refreshToken,
+	})
+}
+
+// LogoutController mencabut refresh token (beserta family-nya) dan access token yang sedang dipakai.
+func (h *AuthHandler) LogoutController(c echo.Context) error {
+	principal := middleware.GetPrincipal(c)
+
+	request := refreshTokenRequest{}
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "Fail to parse request body",
+			"error":   err.Error(),
+		})
+	}
+
+	if request.RefreshToken != "" {
+		err := h.Auth.RevokeRefreshToken(request.RefreshToken, principal.Type, principal.ID)
+		if err != nil && !errors.Is(err, middleware.ErrRefreshTokenInvalid) {
+			return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+				"message": "Gagal logout",
+				"error":   err.Error(),
+			})
+		}
+	}
+
+	if err := h.Auth.RevokeAccessToken(c); err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": "Gagal logout",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Success logout",
+	})
+}
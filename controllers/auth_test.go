@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"miniproject/constants"
+	"miniproject/helpers"
+	"miniproject/middleware"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func refreshRequest(h *AuthHandler, refreshToken string) *httptest.ResponseRecorder {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/auth/refresh", strings.NewReader(`{"refresh_token": "`+refreshToken+`"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	h.RefreshTokenController(e.NewContext(req, rec))
+	return rec
+}
+
+func TestRefreshTokenController(t *testing.T) {
+	repos, userHandler, _ := newTestHandlers()
+	h := NewAuthHandler(repos, userHandler.Auth)
+	user := seedUser(t, repos, "rara", "rara@gmail.com", "rara12", true)
+	first, err := h.Auth.IssueRefreshToken(constants.SubjectUser, user.ID, "")
+	assert.NoError(t, err)
+
+	rec := refreshRequest(h, first)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"token"`)
+	assert.NotContains(t, rec.Body.String(), first)
+
+	// Refresh token yang sudah dirotasi tidak dapat dipakai lagi
+	rec = refreshRequest(h, first)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), middleware.ErrRefreshTokenReused.Error())
+
+	rec = refreshRequest(h, "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestLogoutController(t *testing.T) {
+	repos, userHandler, _ := newTestHandlers()
+	h := NewAuthHandler(repos, userHandler.Auth)
+	user := seedUser(t, repos, "rara", "rara@gmail.com", "rara12", true)
+	refreshToken, err := h.Auth.IssueRefreshToken(constants.SubjectUser, user.ID, "")
+	assert.NoError(t, err)
+
+	claims := jwt.MapClaims{
+		"jti":      "akses-logout",
+		"sub_type": constants.SubjectUser,
+		"userId":   float64(user.ID),
+		"iat":      float64(time.Now().UnixMilli()) / 1000,
+		"exp":      float64(time.Now().Add(time.Hour).Unix()),
+	}
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/auth/logout", strings.NewReader(`{"refresh_token": "`+refreshToken+`"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &jwt.Token{Valid: true, Claims: claims})
+	middleware.SetPrincipal(c, userPrincipal(user))
+
+	if assert.NoError(t, h.LogoutController(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+	assert.True(t, h.Auth.IsTokenRevoked(claims))
+	stored, err := repos.Sessions.FindRefreshToken(helpers.HashToken(refreshToken))
+	if assert.NoError(t, err) {
+		assert.NotNil(t, stored.RevokedAt)
+	}
+	assert.Equal(t, http.StatusUnauthorized, refreshRequest(h, refreshToken).Code)
+}
@@ -0,0 +1,236 @@
+package controllers
+
+import (
+	"errors"
+	"mime/multipart"
+	applicationusecase "miniproject/applications/usecase"
+	"miniproject/constants"
+	documentusecase "miniproject/documents/usecase"
+	"miniproject/middleware"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// UploadDocumentController menerima unggahan multipart (field "type" dan "file") berupa CV,
+// transkrip, atau surat rekomendasi untuk formulir milik pengguna yang sedang login.
+func (h *UserHandler) UploadDocumentController(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "ID tidak valid",
+		})
+	}
+
+	application, err := h.Applications.GetApplication(uint(id))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"message": "Formulir aplikasi tidak ditemukan",
+		})
+	}
+
+	// Hanya pemilik formulir yang dapat mengunggah dokumen
+	principal := middleware.GetPrincipal(c)
+	if principal == nil || principal.Type != constants.SubjectUser || principal.ID != application.UserID {
+		return middleware.Forbidden(c)
+	}
+
+	file, fileName, err := formFile(c)
+	if err != nil {
+		return documentError(c, err)
+	}
+	defer file.Close()
+
+	document, err := h.Documents.Upload(application, c.FormValue("type"), fileName, file)
+	if err != nil {
+		return documentError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"message":  "Dokumen berhasil diunggah",
+		"document": document,
+	})
+}
+
+// UploadAnswerController menerima unggahan multipart (field "file") sebagai jawaban pertanyaan
+// berkas dengan key :key pada formulir milik pengguna yang sedang login.
+func (h *UserHandler) UploadAnswerController(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "ID tidak valid",
+		})
+	}
+
+	application, err := h.Applications.GetApplication(uint(id))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"message": "Formulir aplikasi tidak ditemukan",
+		})
+	}
+
+	principal := middleware.GetPrincipal(c)
+	if principal == nil || principal.Type != constants.SubjectUser || principal.ID != application.UserID {
+		return middleware.Forbidden(c)
+	}
+
+	question, err := h.Applications.GetFileQuestion(application, c.Param("key"))
+	if err != nil {
+		if errors.Is(err, applicationusecase.ErrQuestionNotFound) || errors.Is(err, applicationusecase.ErrListingNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]interface{}{
+				"message": err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": "Gagal mengambil pertanyaan formulir",
+			"error":   err.Error(),
+		})
+	}
+	// Tolak sebelum mengunggah agar tidak ada berkas yatim untuk formulir yang sudah diajukan
+	if application.Status != constants.StatusDraft {
+		return c.JSON(http.StatusConflict, map[string]interface{}{
+			"message": applicationusecase.ErrApplicationClosed.Error(),
+		})
+	}
+
+	file, fileName, err := formFile(c)
+	if err != nil {
+		return documentError(c, err)
+	}
+	defer file.Close()
+
+	document, err := h.Documents.UploadAnswer(application, question, fileName, file)
+	if err != nil {
+		return documentError(c, err)
+	}
+	if err := h.Applications.AttachFileAnswer(application, question, document.ID); err != nil {
+		if errors.Is(err, applicationusecase.ErrApplicationClosed) {
+			return c.JSON(http.StatusConflict, map[string]interface{}{
+				"message": err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": "Gagal menyimpan jawaban formulir",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"message":  "Jawaban berkas berhasil diunggah",
+		"document": document,
+	})
+}
+
+// errMissingFile dikembalikan formFile jika request tidak memiliki field "file".
+var errMissingFile = errors.New("Berkas dokumen wajib diunggah")
+
+// formFile membuka berkas yang diunggah pada field "file" beserta nama aslinya.
+func formFile(c echo.Context) (multipart.File, string, error) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return nil, "", errMissingFile
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, "", err
+	}
+	return file, fileHeader.Filename, nil
+}
+
+// documentError memetakan error unggah dokumen ke respons HTTP.
+func documentError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, errMissingFile):
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message":     "Data dokumen tidak valid",
+			"invalidData": map[string]string{"file": err.Error()},
+		})
+	case errors.Is(err, documentusecase.ErrInvalidDocumentType):
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message":     "Data dokumen tidak valid",
+			"invalidData": map[string]string{"type": err.Error()},
+		})
+	case errors.Is(err, documentusecase.ErrUnsupportedContentType), errors.Is(err, documentusecase.ErrEmptyDocument):
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message":     "Data dokumen tidak valid",
+			"invalidData": map[string]string{"file": err.Error()},
+		})
+	case errors.Is(err, documentusecase.ErrDocumentTooLarge):
+		return c.JSON(http.StatusRequestEntityTooLarge, map[string]interface{}{
+			"message": err.Error(),
+		})
+	case errors.Is(err, documentusecase.ErrTooManyDocuments), errors.Is(err, documentusecase.ErrApplicationClosed):
+		return c.JSON(http.StatusConflict, map[string]interface{}{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+		"message": "Gagal menyimpan dokumen",
+		"error":   err.Error(),
+	})
+}
+
+// GetApplicationDocumentsController menampilkan daftar dokumen formulir kepada pemiliknya
+// atau admin.
+func (h *UserHandler) GetApplicationDocumentsController(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "ID tidak valid",
+		})
+	}
+
+	application, err := h.Applications.GetApplication(uint(id))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"message": "Formulir aplikasi tidak ditemukan",
+		})
+	}
+	if !middleware.OwnerOrAdmin(middleware.GetPrincipal(c), application.UserID) {
+		return middleware.Forbidden(c)
+	}
+
+	documents, err := h.Documents.GetDocuments(application.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": "Gagal mengambil dokumen",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":   "Daftar dokumen formulir aplikasi",
+		"documents": documents,
+	})
+}
+
+// GetApplicationDocumentLinksController menampilkan dokumen kandidat beserta URL unduhan
+// bertanda tangan yang hanya berlaku sementara.
+func (h *AdminHandler) GetApplicationDocumentLinksController(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "ID tidak valid",
+		})
+	}
+
+	if _, err := h.Applications.GetApplication(uint(id)); err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"message": "Formulir aplikasi tidak ditemukan",
+		})
+	}
+
+	links, err := h.Documents.GetDownloadLinks(uint(id))
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": "Gagal membuat URL unduhan dokumen",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":   "Daftar dokumen kandidat",
+		"documents": links,
+	})
+}
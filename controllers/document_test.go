@@ -0,0 +1,162 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"miniproject/constants"
+	documentusecase "miniproject/documents/usecase"
+	"miniproject/entity"
+	"miniproject/infra/storage"
+	"miniproject/middleware"
+	"miniproject/repository"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// useTestStorage memasang penyimpanan lokal di direktori sementara pada kedua handler.
+func useTestStorage(t *testing.T, repos *repository.Repositories, h *UserHandler, adminHandler *AdminHandler) {
+	store, err := storage.NewLocal(t.TempDir(), "http://localhost/files", []byte("secret"))
+	assert.NoError(t, err)
+	documents := documentusecase.NewDocumentUsecase(repos.Documents, store)
+	h.Documents = documents
+	adminHandler.Documents = documents
+}
+
+// newUploadContext membuat context request multipart berisi fields dan berkas pada field "file".
+func newUploadContext(t *testing.T, path string, fields map[string]string, content []byte) (echo.Context, *httptest.ResponseRecorder) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	for name, value := range fields {
+		assert.NoError(t, writer.WriteField(name, value))
+	}
+	part, err := writer.CreateFormFile("file", "dokumen.pdf")
+	assert.NoError(t, err)
+	_, err = part.Write(content)
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, path, body)
+	req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+	rec := httptest.NewRecorder()
+	return e.NewContext(req, rec), rec
+}
+
+func uploadDocument(t *testing.T, h *UserHandler, principal *middleware.Principal, applicationID uint, docType string, content []byte) *httptest.ResponseRecorder {
+	c, rec := newUploadContext(t, "/users/apply-for-internship/1/documents", map[string]string{"type": docType}, content)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(int(applicationID)))
+	middleware.SetPrincipal(c, principal)
+	assert.NoError(t, h.UploadDocumentController(c))
+	return rec
+}
+
+func TestUploadDocumentController(t *testing.T) {
+	repos, h, adminHandler := newTestHandlers()
+	useTestStorage(t, repos, h, adminHandler)
+	user := seedUser(t, repos, "rara", "rara@gmail.com", "rara12", true)
+	other := seedUser(t, repos, "budi", "budi@gmail.com", "budi12", true)
+	application := seedApplication(t, repos, user)
+	pdf := []byte("%PDF-1.4\n1 0 obj\n<<>>\nendobj\n")
+
+	rec := uploadDocument(t, h, userPrincipal(other), application.ID, "cv", pdf)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = uploadDocument(t, h, userPrincipal(user), application.ID, "ktp", pdf)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "invalidData")
+
+	rec = uploadDocument(t, h, userPrincipal(user), application.ID, "cv", []byte("bukan dokumen"))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = uploadDocument(t, h, userPrincipal(user), application.ID, "cv", pdf)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var response struct {
+		Document entity.ApplicationDocument `json:"document"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "application/pdf", response.Document.ContentType)
+	assert.NotContains(t, rec.Body.String(), "applications/")
+}
+
+func TestGetApplicationDocumentLinksController(t *testing.T) {
+	repos, h, adminHandler := newTestHandlers()
+	useTestStorage(t, repos, h, adminHandler)
+	user := seedUser(t, repos, "rara", "rara@gmail.com", "rara12", true)
+	admin := seedAdmin(t, repos, "admin", "admin@gmail.com", "admin12")
+	application := seedApplication(t, repos, user)
+	rec := uploadDocument(t, h, userPrincipal(user), application.ID, "transcript", []byte("%PDF-1.4\n"))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/admin/applications/1/documents", nil)
+	rec = httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(int(application.ID)))
+	middleware.SetPrincipal(c, adminPrincipal(admin))
+	assert.NoError(t, adminHandler.GetApplicationDocumentLinksController(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var response struct {
+		Documents []documentusecase.DownloadLink `json:"documents"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	if assert.Len(t, response.Documents, 1) {
+		assert.True(t, strings.HasPrefix(response.Documents[0].URL, "http://localhost/files/applications/"))
+		assert.Contains(t, response.Documents[0].URL, "signature=")
+	}
+}
+
+func TestUploadAnswerController(t *testing.T) {
+	repos, h, adminHandler := newTestHandlers()
+	useTestStorage(t, repos, h, adminHandler)
+	user := seedUser(t, repos, "rara", "rara@gmail.com", "rara12", true)
+	application := seedApplication(t, repos, user)
+	listing, err := repos.Listings.FindByID(application.InternshipListingID)
+	assert.NoError(t, err)
+	listing.Questions = []entity.ApplicationQuestion{{Key: "portfolio", Label: "Portofolio", Type: constants.QuestionFile}}
+	assert.NoError(t, repos.Listings.Save(listing))
+
+	upload := func(key string) *httptest.ResponseRecorder {
+		c, rec := newUploadContext(t, "/users/apply-for-internship/1/answers/"+key, nil, []byte("%PDF-1.4\n"))
+		c.SetParamNames("id", "key")
+		c.SetParamValues(strconv.Itoa(int(application.ID)), key)
+		middleware.SetPrincipal(c, userPrincipal(user))
+		assert.NoError(t, h.UploadAnswerController(c))
+		return rec
+	}
+
+	rec := upload("motivation")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	// Formulir yang sudah diajukan tidak dapat mengganti jawaban berkas
+	rec = upload("portfolio")
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	documents, err := repos.Documents.FindByApplicationID(application.ID)
+	assert.NoError(t, err)
+	assert.Empty(t, documents)
+
+	application.Status = constants.StatusDraft
+	assert.NoError(t, repos.Applications.Save(application))
+	rec = upload("portfolio")
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var response struct {
+		Document entity.ApplicationDocument `json:"document"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "portfolio", response.Document.QuestionKey)
+
+	stored, err := repos.Applications.FindByID(application.ID)
+	if assert.NoError(t, err) && assert.Len(t, stored.Answers, 1) {
+		assert.Equal(t, response.Document.ID, stored.Answers[0].DocumentID)
+		assert.Equal(t, "Portofolio", stored.Answers[0].Label)
+	}
+}
@@ -0,0 +1,135 @@
+package controllers
+
+import (
+	applicationusecase "miniproject/applications/usecase"
+	"miniproject/constants"
+	"miniproject/entity"
+	"miniproject/middleware"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// draftRequest adalah isi request draft formulir; field yang tidak dikirim tidak diubah.
+type draftRequest struct {
+	ListingID      uint                       `json:"internshiplistingID"`
+	Nim            *string                    `json:"nim"`
+	GPA            *float64                   `json:"gpa"`
+	EducationLevel *string                    `json:"education_level"`
+	Answers        []entity.ApplicationAnswer `json:"answers"`
+}
+
+func (r draftRequest) changes() applicationusecase.DraftChanges {
+	return applicationusecase.DraftChanges{
+		Nim:            r.Nim,
+		GPA:            r.GPA,
+		EducationLevel: r.EducationLevel,
+		Answers:        r.Answers,
+	}
+}
+
+// CreateDraftController menyimpan draft formulir pendaftaran yang boleh belum lengkap. Draft
+// belum memakai kuota sampai diajukan lewat SubmitDraftController.
+func (h *UserHandler) CreateDraftController(c echo.Context) error {
+	principal := middleware.GetPrincipal(c)
+	if principal == nil || principal.User == nil {
+		return middleware.Forbidden(c)
+	}
+
+	var request draftRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "Gagal menyimpan draft formulir",
+			"error":   err.Error(),
+		})
+	}
+
+	draft, err := h.Applications.CreateDraft(principal.User, request.ListingID, request.changes())
+	if err != nil {
+		return applyError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"message":     "Draft formulir berhasil disimpan",
+		"application": draft,
+	})
+}
+
+// UpdateDraftController memperbarui sebagian isi draft milik pengguna yang sedang login.
+func (h *UserHandler) UpdateDraftController(c echo.Context) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "ID tidak valid",
+		})
+	}
+
+	application, err := h.Applications.GetApplication(uint(id))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"message": "Formulir aplikasi tidak ditemukan",
+		})
+	}
+	principal := middleware.GetPrincipal(c)
+	if principal == nil || principal.Type != constants.SubjectUser || principal.ID != application.UserID {
+		return middleware.Forbidden(c)
+	}
+
+	var request draftRequest
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "Gagal menyimpan draft formulir",
+			"error":   err.Error(),
+		})
+	}
+
+	draft, err := h.Applications.UpdateDraft(application, request.changes())
+	if err != nil {
+		return applyError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":     "Draft formulir berhasil diperbarui",
+		"application": draft,
+	})
+}
+
+// SubmitDraftController mengajukan draft milik pengguna yang sedang login. Draft divalidasi
+// lengkap dan baru memakai kuota lowongan pada tahap ini.
+func (h *UserHandler) SubmitDraftController(c echo.Context) error {
+	// Hanya akun dengan email terverifikasi yang boleh mendaftar magang
+	principal := middleware.GetPrincipal(c)
+	if principal == nil || principal.User == nil || principal.User.EmailVerifiedAt == nil {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"message": constants.ErrEmailNotVerified,
+		})
+	}
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "ID tidak valid",
+		})
+	}
+
+	application, err := h.Applications.GetApplication(uint(id))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"message": "Formulir aplikasi tidak ditemukan",
+		})
+	}
+	if principal.ID != application.UserID {
+		return middleware.Forbidden(c)
+	}
+
+	submitted, err := h.Applications.SubmitDraft(application)
+	if err != nil {
+		return applyError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":     "Pendaftaran magang berhasil diajukan",
+		"application": submitted,
+	})
+}
@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"encoding/json"
+	"miniproject/constants"
+	"miniproject/entity"
+	"miniproject/middleware"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDraftLifecycle(t *testing.T) {
+	repos, h, _ := newTestHandlers()
+	user := seedUser(t, repos, "raras", "raras@gmail.com", "raras12", true)
+	other := seedUser(t, repos, "budi", "budi@gmail.com", "budi12", true)
+	listing := &entity.Internship_Listing{Title: "Software Engineer", Quota: 2, Status: constants.ListingPublished}
+	assert.NoError(t, repos.Listings.Create(listing))
+
+	request := func(method, payload string, handler echo.HandlerFunc, principal *entity.User, id uint) *httptest.ResponseRecorder {
+		e := echo.New()
+		req := httptest.NewRequest(method, "/users/apply-for-internship", strings.NewReader(payload))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		if id != 0 {
+			c.SetParamNames("id")
+			c.SetParamValues(strconv.Itoa(int(id)))
+		}
+		middleware.SetPrincipal(c, userPrincipal(principal))
+		assert.NoError(t, handler(c))
+		return rec
+	}
+
+	rec := request(http.MethodPost, `{"internshiplistingID": `+strconv.Itoa(int(listing.ID))+`, "nim": "123456"}`, h.CreateDraftController, user, 0)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var response struct {
+		Application entity.Internship_ApplicationForm `json:"application"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	draft := response.Application
+	assert.Equal(t, constants.StatusDraft, draft.Status)
+
+	rec = request(http.MethodPatch, `{"gpa": 3.6}`, h.UpdateDraftController, other, draft.ID)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = request(http.MethodPatch, `{"gpa": -1, "education_level": ""}`, h.UpdateDraftController, user, draft.ID)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"gpa"`)
+	assert.Contains(t, rec.Body.String(), `"education_level"`)
+
+	// Draft yang belum lengkap belum dapat diajukan
+	rec = request(http.MethodPost, "", h.SubmitDraftController, user, draft.ID)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "invalidData")
+
+	rec = request(http.MethodPatch, `{"gpa": 3.6, "education_level": "S1"}`, h.UpdateDraftController, user, draft.ID)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = request(http.MethodPost, "", h.SubmitDraftController, user, draft.ID)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	listing, err := repos.Listings.FindByID(listing.ID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, listing.Quota)
+	}
+
+	rec = request(http.MethodPatch, `{"nim": "654321"}`, h.UpdateDraftController, user, draft.ID)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
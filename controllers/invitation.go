@@ -0,0 +1,235 @@
+package controllers
+
+import (
+	"errors"
+	"log"
+	"miniproject/constants"
+	"miniproject/entity"
+	"miniproject/helpers"
+	"miniproject/middleware"
+	"miniproject/repository"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// maxInvitationTTL membatasi masa berlaku undangan yang dapat diminta admin.
+const maxInvitationTTL = time.Hour * 24 * 7
+
+var errInvitationInvalid = errors.New("Undangan tidak valid, sudah dipakai, dicabut, atau kedaluwarsa")
+
+type createInvitationRequest struct {
+	Email          string `json:"email" form:"email"`
+	Role           string `json:"role" form:"role"`
+	ExpiresInHours int    `json:"expires_in_hours" form:"expires_in_hours"`
+}
+
+type acceptInvitationRequest struct {
+	Token    string `json:"token" form:"token"`
+	Username string `json:"username" form:"username"`
+	Password string `json:"password" form:"password"`
+}
+
+// invitationTTL membaca masa berlaku default undangan (jam) dari ADMININVITETTL, default 72 jam.
+func invitationTTL() time.Duration {
+	hours, err := strconv.Atoi(os.Getenv("ADMININVITETTL"))
+	if err != nil || hours <= 0 {
+		return time.Hour * 72
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// CreateAdminInvitationController membuat undangan admin baru dan mengirim tautannya ke email tujuan.
+// Undangan lama yang masih aktif untuk email yang sama otomatis dicabut.
+func (h *AdminHandler) CreateAdminInvitationController(c echo.Context) error {
+	principal := middleware.GetPrincipal(c)
+
+	request := createInvitationRequest{}
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "Fail to parse request body",
+			"error":   err.Error(),
+		})
+	}
+	request.Email = strings.TrimSpace(request.Email)
+	if request.Role == "" {
+		request.Role = constants.RoleAdmin
+	}
+
+	ttl := invitationTTL()
+	if request.ExpiresInHours != 0 {
+		ttl = time.Duration(request.ExpiresInHours) * time.Hour
+	}
+
+	invalidData := make(map[string]string)
+	if request.Email == "" || !strings.Contains(request.Email, "@") {
+		invalidData["email"] = "Email tidak valid"
+	}
+	if request.Role != constants.RoleAdmin {
+		invalidData["role"] = "Role undangan hanya boleh 'admin'"
+	}
+	if ttl <= 0 || ttl > maxInvitationTTL {
+		invalidData["expires_in_hours"] = "Masa berlaku undangan harus antara 1 dan 168 jam"
+	}
+	if len(invalidData) > 0 {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message":     "Data undangan tidak valid",
+			"invalidData": invalidData,
+		})
+	}
+
+	if _, err := h.Admins.FindByEmail(request.Email); err == nil {
+		return c.JSON(http.StatusConflict, map[string]interface{}{
+			"message": constants.ErrUserAlreadyExists,
+		})
+	}
+
+	token, err := helpers.GenerateToken()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": constants.ErrTokenCreationFailed,
+			"error":   err.Error(),
+		})
+	}
+
+	now := time.Now()
+	invitation := entity.AdminInvitation{
+		Email:       request.Email,
+		Role:        request.Role,
+		TokenHash:   helpers.HashToken(token),
+		ExpiresAt:   now.Add(ttl),
+		InvitedByID: principal.ID,
+	}
+	if err := h.Invitations.Create(&invitation, now); err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": "Gagal membuat undangan admin",
+			"error":   err.Error(),
+		})
+	}
+
+	if err := helpers.SendAdminInvitationEmail(invitation.Email, principal.Username, token); err != nil {
+		log.Println("gagal mengirim email undangan admin:", err)
+	}
+
+	return c.JSON(http.StatusCreated, map[string]interface{}{
+		"message":    "Undangan admin berhasil dikirim",
+		"invitation": invitation,
+	})
+}
+
+// GetAdminInvitationsController menampilkan semua undangan admin beserta statusnya.
+func (h *AdminHandler) GetAdminInvitationsController(c echo.Context) error {
+	invitations, err := h.Invitations.FindAll()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": "Gagal mengambil data undangan",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":     "Daftar undangan admin",
+		"invitations": invitations,
+	})
+}
+
+// RevokeAdminInvitationController mencabut undangan yang belum diterima.
+func (h *AdminHandler) RevokeAdminInvitationController(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "Invalid invitation ID",
+			"error":   err.Error(),
+		})
+	}
+
+	revoked, err := h.Invitations.Revoke(uint(id), time.Now())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": "Gagal mencabut undangan",
+			"error":   err.Error(),
+		})
+	}
+	if !revoked {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"message": "Undangan tidak ditemukan atau sudah tidak aktif",
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Undangan berhasil dicabut",
+	})
+}
+
+// AcceptAdminInvitationController menyelesaikan pendaftaran admin menggunakan token undangan.
+// Email akun diambil dari undangan, bukan dari request.
+func (h *AdminHandler) AcceptAdminInvitationController(c echo.Context) error {
+	request := acceptInvitationRequest{}
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "Fail to parse request body",
+			"error":   err.Error(),
+		})
+	}
+
+	invalidData := make(map[string]string)
+	if request.Token == "" {
+		invalidData["token"] = "Token is required"
+	}
+	if request.Username == "" {
+		invalidData["username"] = "Username is required"
+	}
+	if request.Password == "" {
+		invalidData["password"] = "Password is required"
+	}
+	if len(invalidData) > 0 {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message":     "Data pendaftaran admin tidak valid",
+			"invalidData": invalidData,
+		})
+	}
+
+	hashedPassword, err := helpers.HashPassword(request.Password)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": constants.ErrFailedToRegister,
+			"error":   err.Error(),
+		})
+	}
+
+	// Email dan role diisi dari undangan; undangan hanya dapat dipakai satu kali
+	admin := entity.Admin{
+		Username: request.Username,
+		Password: hashedPassword,
+	}
+	err = h.Invitations.Accept(helpers.HashToken(request.Token), &admin, time.Now())
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return c.JSON(http.StatusBadRequest, map[string]interface{}{
+				"message": errInvitationInvalid.Error(),
+			})
+		}
+		if errors.Is(err, repository.ErrConflict) {
+			return c.JSON(http.StatusConflict, map[string]interface{}{
+				"message": constants.ErrUserAlreadyExists,
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": constants.ErrFailedToRegister,
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Success create new admin",
+		"admin": entity.AdminResponse{
+			ID:       admin.ID,
+			Username: admin.Username,
+			Email:    admin.Email,
+		},
+	})
+}
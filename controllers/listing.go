@@ -0,0 +1,162 @@
+package controllers
+
+import (
+	"errors"
+	"miniproject/constants"
+	listingusecase "miniproject/listings/usecase"
+	"miniproject/middleware"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetInternshipListings digunakan untuk mencari lowongan magang dengan filter dan pagination
+// berbasis cursor. Pengguna hanya melihat lowongan yang sedang menerima pendaftaran, sedangkan
+// admin dapat melihat semua lowongan dan memfilter berdasarkan status.
+//
+// Query parameter: q, status (dipisah koma), open, start_from, end_until, has_quota, sort,
+// cursor, dan limit.
+func (h *UserHandler) GetInternshipListings(c echo.Context) error {
+	search, invalidData := parseListingSearch(c)
+	if principal := middleware.GetPrincipal(c); principal == nil || !principal.IsAdmin() {
+		for _, listingStatus := range search.Statuses {
+			if listingStatus != constants.ListingPublished {
+				invalidData["status"] = "Pengguna hanya dapat melihat lowongan published"
+			}
+		}
+		search.Statuses = []string{constants.ListingPublished}
+		search.OpenOnly = true
+	}
+	if len(invalidData) > 0 {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message":     "Parameter pencarian tidak valid",
+			"invalidData": invalidData,
+		})
+	}
+
+	page, err := h.Listings.SearchListings(search)
+	if err != nil {
+		var validationErr *listingusecase.ValidationError
+		if errors.As(err, &validationErr) {
+			return c.JSON(http.StatusBadRequest, map[string]interface{}{
+				"message":     "Parameter pencarian tidak valid",
+				"invalidData": validationErr.Fields,
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": "Gagal mengambil daftar magang",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":     "Daftar magang Terbaru",
+		"listings":    page.Listings,
+		"total":       page.Total,
+		"next_cursor": page.NextCursor,
+	})
+}
+
+// GetInternshipListingByID mengembalikan detail satu lowongan magang. Pengguna hanya dapat
+// melihat lowongan published atau closed; draft dan arsip hanya terlihat oleh admin.
+func (h *UserHandler) GetInternshipListingByID(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "Invalid listing ID",
+			"error":   err.Error(),
+		})
+	}
+
+	listing, err := h.Listings.GetListing(uint(id))
+	if err != nil && !errors.Is(err, listingusecase.ErrListingNotFound) {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": "Gagal mengambil lowongan magang",
+			"error":   err.Error(),
+		})
+	}
+	if listing != nil {
+		if principal := middleware.GetPrincipal(c); principal == nil || !principal.IsAdmin() {
+			if listing.Status != constants.ListingPublished && listing.Status != constants.ListingClosed {
+				listing = nil
+			}
+		}
+	}
+	if listing == nil {
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"message": listingusecase.ErrListingNotFound.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Detail lowongan magang",
+		"listing": listing,
+	})
+}
+
+// parseListingSearch membaca query parameter pencarian lowongan. Nilai yang tidak dapat dibaca
+// dikembalikan sebagai invalidData agar dilaporkan bersama hasil validasi lainnya.
+func parseListingSearch(c echo.Context) (listingusecase.ListingSearch, map[string]string) {
+	invalidData := make(map[string]string)
+	search := listingusecase.ListingSearch{
+		Keyword: strings.TrimSpace(c.QueryParam("q")),
+		Sort:    c.QueryParam("sort"),
+		Cursor:  c.QueryParam("cursor"),
+	}
+	for _, listingStatus := range strings.Split(c.QueryParam("status"), ",") {
+		if listingStatus = strings.TrimSpace(listingStatus); listingStatus != "" {
+			search.Statuses = append(search.Statuses, listingStatus)
+		}
+	}
+
+	var err error
+	if search.OpenOnly, err = parseQueryBool(c, "open"); err != nil {
+		invalidData["open"] = "Nilai open harus true atau false"
+	}
+	if search.HasQuota, err = parseQueryBool(c, "has_quota"); err != nil {
+		invalidData["has_quota"] = "Nilai has_quota harus true atau false"
+	}
+	if search.StartFrom, err = parseQueryDate(c, "start_from", false); err != nil {
+		invalidData["start_from"] = "Format tanggal harus YYYY-MM-DD atau RFC3339"
+	}
+	if search.EndUntil, err = parseQueryDate(c, "end_until", true); err != nil {
+		invalidData["end_until"] = "Format tanggal harus YYYY-MM-DD atau RFC3339"
+	}
+	if value := c.QueryParam("limit"); value != "" {
+		if search.Limit, err = strconv.Atoi(value); err != nil || search.Limit <= 0 {
+			invalidData["limit"] = "Limit harus berupa angka positif"
+		}
+	}
+	return search, invalidData
+}
+
+func parseQueryBool(c echo.Context, name string) (bool, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+// parseQueryDate menerima tanggal YYYY-MM-DD atau RFC3339. Jika endOfDay true, tanggal tanpa jam
+// dianggap sampai akhir hari tersebut.
+func parseQueryDate(c echo.Context, name string, endOfDay bool) (*time.Time, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return nil, nil
+	}
+	if date, err := time.Parse(time.RFC3339, value); err == nil {
+		return &date, nil
+	}
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, err
+	}
+	if endOfDay {
+		date = date.Add(24*time.Hour - time.Nanosecond)
+	}
+	return &date, nil
+}
@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"encoding/json"
+	"miniproject/constants"
+	"miniproject/entity"
+	"miniproject/middleware"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchInternshipListings(t *testing.T) {
+	repos, h, _ := newTestHandlers()
+	admin := seedAdmin(t, repos, "caca", "caca@gmail.com", "caca12")
+	for _, listing := range []*entity.Internship_Listing{
+		{Title: "Backend Developer", Description: "Golang", Quota: 2, Status: constants.ListingPublished},
+		{Title: "Frontend Developer", Quota: 0, Status: constants.ListingPublished},
+		{Title: "Mobile Developer", Quota: 1, Status: constants.ListingPublished},
+		{Title: "DevOps Engineer", Quota: 1},
+	} {
+		assert.NoError(t, repos.Listings.Create(listing))
+	}
+	e := echo.New()
+
+	req := httptest.NewRequest(http.MethodGet, "/listings?q=developer&has_quota=true&sort=title&limit=1", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	if assert.NoError(t, h.GetInternshipListings(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var body struct {
+			Listings   []entity.Internship_Listing `json:"listings"`
+			Total      int64                       `json:"total"`
+			NextCursor string                      `json:"next_cursor"`
+		}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		if assert.Len(t, body.Listings, 1) {
+			assert.Equal(t, "Backend Developer", body.Listings[0].Title)
+		}
+		assert.EqualValues(t, 2, body.Total)
+		assert.NotEmpty(t, body.NextCursor)
+	}
+
+	// Pengguna tidak dapat melihat lowongan draft melalui filter status
+	req = httptest.NewRequest(http.MethodGet, "/listings?status=draft&limit=abc", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+	if assert.NoError(t, h.GetInternshipListings(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"status"`)
+		assert.Contains(t, rec.Body.String(), `"limit"`)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/listings?status=draft", nil)
+	rec = httptest.NewRecorder()
+	c = e.NewContext(req, rec)
+	middleware.SetPrincipal(c, adminPrincipal(admin))
+	if assert.NoError(t, h.GetInternshipListings(c)) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "DevOps Engineer")
+		assert.NotContains(t, rec.Body.String(), "Backend Developer")
+	}
+}
+
+func TestGetInternshipListingByID(t *testing.T) {
+	repos, h, _ := newTestHandlers()
+	user := seedUser(t, repos, "raras", "raras@gmail.com", "raras12", true)
+	admin := seedAdmin(t, repos, "caca", "caca@gmail.com", "caca12")
+	published := &entity.Internship_Listing{Title: "Backend Developer", Quota: 2, Status: constants.ListingPublished}
+	draft := &entity.Internship_Listing{Title: "DevOps Engineer", Quota: 1}
+	assert.NoError(t, repos.Listings.Create(published))
+	assert.NoError(t, repos.Listings.Create(draft))
+	e := echo.New()
+
+	get := func(listing *entity.Internship_Listing, principal *middleware.Principal) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/listings/", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(strconv.Itoa(int(listing.ID)))
+		middleware.SetPrincipal(c, principal)
+		assert.NoError(t, h.GetInternshipListingByID(c))
+		return rec
+	}
+
+	rec := get(published, userPrincipal(user))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Backend Developer")
+
+	rec = get(draft, userPrincipal(user))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = get(draft, adminPrincipal(admin))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "DevOps Engineer")
+}
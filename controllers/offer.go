@@ -0,0 +1,139 @@
+package controllers
+
+import (
+	"errors"
+	applicationusecase "miniproject/applications/usecase"
+	"miniproject/constants"
+	"miniproject/entity"
+	"miniproject/middleware"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+type declineOfferRequest struct {
+	Reason string `json:"reason" form:"reason"`
+}
+
+// GetMyOffersController menampilkan semua tawaran magang milik pengguna yang sedang login.
+func (h *UserHandler) GetMyOffersController(c echo.Context) error {
+	principal := middleware.GetPrincipal(c)
+	if principal == nil || principal.Type != constants.SubjectUser {
+		return middleware.Forbidden(c)
+	}
+
+	offers, err := h.Applications.GetUserOffers(principal.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": "Gagal mengambil tawaran magang",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Daftar tawaran magang",
+		"offers":  offers,
+	})
+}
+
+// AcceptOfferController digunakan kandidat untuk menerima tawaran magang sebelum kedaluwarsa.
+func (h *UserHandler) AcceptOfferController(c echo.Context) error {
+	return h.respondToOffer(c, "Tawaran magang berhasil diterima", h.Applications.AcceptOffer)
+}
+
+// DeclineOfferController digunakan kandidat untuk menolak tawaran magang. Kursinya diberikan
+// kepada kandidat daftar tunggu berikutnya.
+func (h *UserHandler) DeclineOfferController(c echo.Context) error {
+	request := declineOfferRequest{}
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "Fail to parse request body",
+			"error":   err.Error(),
+		})
+	}
+
+	actor := actorOf(middleware.GetPrincipal(c))
+	return h.respondToOffer(c, "Tawaran magang berhasil ditolak", func(offer *entity.Offer) error {
+		return h.Applications.DeclineOffer(offer, actor, strings.TrimSpace(request.Reason))
+	})
+}
+
+// respondToOffer mengambil offer dari parameter id, memastikan offer milik pengguna yang sedang
+// login, lalu menjawabnya dengan respond.
+func (h *UserHandler) respondToOffer(c echo.Context, message string, respond func(offer *entity.Offer) error) error {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "ID tidak valid",
+		})
+	}
+
+	offer, err := h.Applications.GetOffer(uint(id))
+	if err != nil {
+		return offerError(c, err)
+	}
+
+	// Hanya kandidat penerima tawaran yang dapat menjawabnya
+	principal := middleware.GetPrincipal(c)
+	if principal == nil || principal.Type != constants.SubjectUser || principal.ID != offer.UserID {
+		return middleware.Forbidden(c)
+	}
+
+	if err := respond(offer); err != nil {
+		return offerError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": message,
+		"offer":   offer,
+	})
+}
+
+func offerError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, applicationusecase.ErrOfferNotFound), errors.Is(err, applicationusecase.ErrApplicationNotFound):
+		return c.JSON(http.StatusNotFound, map[string]interface{}{
+			"message": err.Error(),
+		})
+	case errors.Is(err, applicationusecase.ErrOfferNotPending), errors.Is(err, applicationusecase.ErrOfferExpired),
+		errors.Is(err, applicationusecase.ErrStatusChanged):
+		return c.JSON(http.StatusConflict, map[string]interface{}{
+			"message": err.Error(),
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+		"message": "Gagal memproses tawaran magang",
+		"error":   err.Error(),
+	})
+}
+
+// GetListingOffersController menampilkan tawaran magang pada satu lowongan beserta status
+// konfirmasinya.
+func (h *AdminHandler) GetListingOffersController(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "ID tidak valid",
+		})
+	}
+
+	offers, err := h.Applications.GetListingOffers(uint(id))
+	if err != nil {
+		if errors.Is(err, applicationusecase.ErrListingNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]interface{}{
+				"message": err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": "Gagal mengambil tawaran magang",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Daftar tawaran magang",
+		"offers":  offers,
+	})
+}
@@ -0,0 +1,240 @@
+package controllers
+
+import (
+	"errors"
+	"html"
+	"log"
+	"miniproject/constants"
+	"miniproject/entity"
+	"miniproject/helpers"
+	"miniproject/middleware"
+	"miniproject/repository"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+var errResetTokenInvalid = errors.New("Token reset password tidak valid atau sudah kedaluwarsa")
+
+type forgotPasswordRequest struct {
+	Email string `json:"email" form:"email"`
+}
+
+type resetPasswordRequest struct {
+	Token    string `json:"token" form:"token"`
+	Password string `json:"password" form:"password"`
+}
+
+// passwordResetTTL membaca masa berlaku token reset (menit) dari PASSWORDRESETTTL, default 30 menit.
+func passwordResetTTL() time.Duration {
+	minutes, err := strconv.Atoi(os.Getenv("PASSWORDRESETTTL"))
+	if err != nil || minutes <= 0 {
+		return time.Minute * 30
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// passwordResetPage membaca URL halaman reset password di front-end dari USERPASSWORDRESETURL atau
+// ADMINPASSWORDRESETURL. Jika tidak diset, tautan di email mengarah ke form bawaan (GET resetPath).
+func passwordResetPage(subjectType, resetPath string) string {
+	key := "USERPASSWORDRESETURL"
+	if subjectType == constants.SubjectAdmin {
+		key = "ADMINPASSWORDRESETURL"
+	}
+	if page := os.Getenv(key); page != "" {
+		return page
+	}
+	return helpers.AppURL(resetPath)
+}
+
+// ForgotUserPasswordController mengirim tautan reset password ke email pengguna.
+func (h *UserHandler) ForgotUserPasswordController(c echo.Context) error {
+	return forgotPassword(c, h.PasswordResets, h.Throttle, constants.SubjectUser, "/users/password/reset", func(email string) (uint, string, error) {
+		user, err := h.Users.FindByEmail(email)
+		if err != nil {
+			return 0, "", err
+		}
+		return user.ID, user.Username, nil
+	})
+}
+
+// ResetUserPasswordFormController menampilkan form reset password bawaan untuk tautan di email.
+func (h *UserHandler) ResetUserPasswordFormController(c echo.Context) error {
+	return resetPasswordForm(c, "/users/password/reset")
+}
+
+// ResetUserPasswordController mengganti password pengguna menggunakan token reset.
+func (h *UserHandler) ResetUserPasswordController(c echo.Context) error {
+	return resetPassword(c, h.PasswordResets, h.Auth, constants.SubjectUser)
+}
+
+// ForgotAdminPasswordController mengirim tautan reset password ke email admin.
+func (h *AdminHandler) ForgotAdminPasswordController(c echo.Context) error {
+	return forgotPassword(c, h.PasswordResets, h.Throttle, constants.SubjectAdmin, "/admin/password/reset", func(email string) (uint, string, error) {
+		admin, err := h.Admins.FindByEmail(email)
+		if err != nil {
+			return 0, "", err
+		}
+		return admin.ID, admin.Username, nil
+	})
+}
+
+// ResetAdminPasswordFormController menampilkan form reset password bawaan untuk tautan di email.
+func (h *AdminHandler) ResetAdminPasswordFormController(c echo.Context) error {
+	return resetPasswordForm(c, "/admin/password/reset")
+}
+
+// ResetAdminPasswordController mengganti password admin menggunakan token reset.
+func (h *AdminHandler) ResetAdminPasswordController(c echo.Context) error {
+	return resetPassword(c, h.PasswordResets, h.Auth, constants.SubjectAdmin)
+}
+
+// forgotPassword membuat token reset untuk akun dengan email pada request. findByEmail
+// mengembalikan ID dan username akun sesuai tipe subject. Setiap permintaan dihitung oleh
+// throttle login per email dan IP, dan email dikirim di latar belakang agar waktu respons
+// tidak membedakan email yang terdaftar.
+func forgotPassword(c echo.Context, resets repository.PasswordResetRepository, throttle *helpers.LoginThrottler,
+	subjectType, resetPath string, findByEmail func(email string) (uint, string, error)) error {
+	request := forgotPasswordRequest{}
+	if err := c.Bind(&request); err != nil || request.Email == "" {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "Email wajib diisi",
+		})
+	}
+
+	throttleKeys := helpers.PasswordResetThrottleKeys(subjectType, request.Email, c.RealIP())
+	retryAfter, err := throttle.Check(throttleKeys)
+	if err != nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]interface{}{
+			"message": constants.ErrResetUnavailable,
+			"error":   err.Error(),
+		})
+	}
+	if retryAfter > 0 {
+		return tooManyRequests(c, retryAfter, constants.ErrTooManyResets)
+	}
+	if err := throttle.RecordFailure(throttleKeys); err != nil {
+		log.Println("gagal mencatat permintaan reset password:", err)
+	}
+
+	// Respons selalu sama agar tidak membocorkan apakah email terdaftar
+	response := map[string]interface{}{
+		"message": "Jika email terdaftar, tautan reset password telah dikirim",
+	}
+
+	subjectID, username, err := findByEmail(request.Email)
+	if err != nil {
+		return c.JSON(http.StatusOK, response)
+	}
+
+	token, err := helpers.GenerateToken()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": constants.ErrTokenCreationFailed,
+			"error":   err.Error(),
+		})
+	}
+
+	// Token lama yang belum dipakai tidak berlaku lagi setelah permintaan baru
+	now := time.Now()
+	err = resets.Replace(&entity.PasswordResetToken{
+		SubjectType: subjectType,
+		SubjectID:   subjectID,
+		TokenHash:   helpers.HashToken(token),
+		ExpiresAt:   now.Add(passwordResetTTL()),
+	}, now)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": "Gagal memproses permintaan reset password",
+			"error":   err.Error(),
+		})
+	}
+
+	resetPage := passwordResetPage(subjectType, resetPath)
+	go func() {
+		if err := helpers.SendPasswordResetEmail(request.Email, username, resetPage, token); err != nil {
+			log.Println("gagal mengirim email reset password:", err)
+		}
+	}()
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// resetPasswordForm menampilkan form HTML yang mengirim token dan password baru ke resetPath
+// (POST). Token tidak diperiksa di sini karena tetap divalidasi saat form dikirim.
+func resetPasswordForm(c echo.Context, resetPath string) error {
+	token := html.EscapeString(c.QueryParam("token"))
+	return c.HTML(http.StatusOK, `<!DOCTYPE html>
+<html>
+<head>
+	<meta charset="UTF-8">
+	<title>Reset Password</title>
+</head>
+<body style="font-family: Arial, sans-serif;">
+	<h1>Reset Password</h1>
+	<form method="POST" action="`+html.EscapeString(helpers.AppURL(resetPath))+`">
+		<input type="hidden" name="token" value="`+token+`">
+		<label>Password baru <input type="password" name="password" required></label>
+		<button type="submit">Reset Password</button>
+	</form>
+</body>
+</html>`)
+}
+
+func resetPassword(c echo.Context, resets repository.PasswordResetRepository, auth *middleware.Auth, subjectType string) error {
+	request := resetPasswordRequest{}
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "Fail to parse request body",
+			"error":   err.Error(),
+		})
+	}
+
+	invalidData := make(map[string]string)
+	if request.Token == "" {
+		invalidData["token"] = "Token is required"
+	}
+	if request.Password == "" {
+		invalidData["password"] = "Password is required"
+	}
+	if len(invalidData) > 0 {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message":     "Data reset password tidak valid",
+			"invalidData": invalidData,
+		})
+	}
+
+	hashedPassword, err := helpers.HashPassword(request.Password)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": "Gagal mereset password",
+			"error":   err.Error(),
+		})
+	}
+
+	// Token hanya dapat dipakai satu kali; penggantian password dan penandaan token dilakukan bersamaan
+	resetToken, err := resets.ResetPassword(helpers.HashToken(request.Token), subjectType, hashedPassword, time.Now())
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return c.JSON(http.StatusBadRequest, map[string]interface{}{
+				"message": errResetTokenInvalid.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": "Gagal mereset password",
+			"error":   err.Error(),
+		})
+	}
+
+	// Password baru membatalkan semua sesi yang sudah diterbitkan
+	if err := auth.RevokeSubjectSessions(subjectType, resetToken.SubjectID); err != nil {
+		log.Println("gagal mencabut sesi setelah reset password:", err)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Password berhasil direset",
+	})
+}
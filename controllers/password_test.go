@@ -0,0 +1,143 @@
+package controllers
+
+import (
+	"miniproject/constants"
+	"miniproject/entity"
+	"miniproject/helpers"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func seedResetToken(t *testing.T, h *UserHandler, user *entity.User, token string, expiresAt time.Time) {
+	assert.NoError(t, h.PasswordResets.Replace(&entity.PasswordResetToken{
+		SubjectType: constants.SubjectUser,
+		SubjectID:   user.ID,
+		TokenHash:   helpers.HashToken(token),
+		ExpiresAt:   expiresAt,
+	}, time.Now()))
+}
+
+func resetUserPassword(t *testing.T, h *UserHandler, token, password string) *httptest.ResponseRecorder {
+	e := echo.New()
+	payload := `{"token": "` + token + `", "password": "` + password + `"}`
+	req := httptest.NewRequest(http.MethodPost, "/users/password/reset", strings.NewReader(payload))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	assert.NoError(t, h.ResetUserPasswordController(e.NewContext(req, rec)))
+	return rec
+}
+
+func TestResetUserPasswordSingleUse(t *testing.T) {
+	repos, h, _ := newTestHandlers()
+	user := seedUser(t, repos, "rara", "rara@gmail.com", "rara12", true)
+	refreshToken, err := h.Auth.IssueRefreshToken(constants.SubjectUser, user.ID, "")
+	assert.NoError(t, err)
+	seedResetToken(t, h, user, "token-reset", time.Now().Add(time.Hour))
+
+	rec := resetUserPassword(t, h, "token-reset", "rahasia-baru")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	updated, err := repos.Users.FindByID(user.ID)
+	if assert.NoError(t, err) {
+		match, _ := helpers.VerifyPassword(updated.Password, "rahasia-baru")
+		assert.True(t, match)
+	}
+	// Reset password membatalkan sesi yang sudah ada
+	_, _, err = h.Auth.RotateRefreshToken(refreshToken)
+	assert.Error(t, err)
+
+	// Token yang sudah dipakai tidak dapat dipakai lagi
+	rec = resetUserPassword(t, h, "token-reset", "rahasia-lain")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), errResetTokenInvalid.Error())
+}
+
+func TestResetUserPasswordExpiredOrReplaced(t *testing.T) {
+	repos, h, _ := newTestHandlers()
+	user := seedUser(t, repos, "rara", "rara@gmail.com", "rara12", true)
+
+	seedResetToken(t, h, user, "token-lama", time.Now().Add(-time.Minute))
+	rec := resetUserPassword(t, h, "token-lama", "rahasia-baru")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// Permintaan reset baru membatalkan token yang belum dipakai
+	seedResetToken(t, h, user, "token-pertama", time.Now().Add(time.Hour))
+	seedResetToken(t, h, user, "token-kedua", time.Now().Add(time.Hour))
+	assert.Equal(t, http.StatusBadRequest, resetUserPassword(t, h, "token-pertama", "rahasia-baru").Code)
+	assert.Equal(t, http.StatusOK, resetUserPassword(t, h, "token-kedua", "rahasia-baru").Code)
+
+	updated, err := repos.Users.FindByID(user.ID)
+	if assert.NoError(t, err) {
+		match, _ := helpers.VerifyPassword(updated.Password, "rara12")
+		assert.False(t, match)
+	}
+}
+
+func TestResetUserPasswordForm(t *testing.T) {
+	_, h, _ := newTestHandlers()
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/users/password/reset?token=abc%22def", nil)
+	rec := httptest.NewRecorder()
+
+	if assert.NoError(t, h.ResetUserPasswordFormController(e.NewContext(req, rec))) {
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `method="POST"`)
+		assert.Contains(t, rec.Body.String(), `value="abc&#34;def"`)
+	}
+}
+
+func TestPasswordResetPage(t *testing.T) {
+	t.Setenv("APPBASEURL", "https://api.example.com")
+	t.Setenv("ADMINPASSWORDRESETURL", "")
+	t.Setenv("USERPASSWORDRESETURL", "https://app.example.com/reset-password")
+
+	assert.Equal(t, "https://app.example.com/reset-password", passwordResetPage(constants.SubjectUser, "/users/password/reset"))
+	assert.Equal(t, "https://api.example.com/admin/password/reset", passwordResetPage(constants.SubjectAdmin, "/admin/password/reset"))
+}
+
+func forgotUserPassword(t *testing.T, h *UserHandler, email string) *httptest.ResponseRecorder {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/users/password/forgot", strings.NewReader(`{"email": "`+email+`"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	assert.NoError(t, h.ForgotUserPasswordController(e.NewContext(req, rec)))
+	return rec
+}
+
+func TestForgotUserPasswordThrottled(t *testing.T) {
+	repos, h, _ := newTestHandlers()
+	seedUser(t, repos, "rara", "rara@gmail.com", "rara12", true)
+
+	// Email terdaftar dan tidak terdaftar mendapat respons yang sama
+	registered := forgotUserPassword(t, h, "rara@gmail.com")
+	unknown := forgotUserPassword(t, h, "lain@gmail.com")
+	assert.Equal(t, http.StatusOK, registered.Code)
+	assert.Equal(t, registered.Body.String(), unknown.Body.String())
+
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, http.StatusOK, forgotUserPassword(t, h, "rara@gmail.com").Code)
+	}
+	rec := forgotUserPassword(t, h, "rara@gmail.com")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Contains(t, rec.Body.String(), constants.ErrTooManyResets)
+	assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+
+	// Permintaan reset tidak ikut mengunci login akun yang sama
+	retryAfter, err := h.Throttle.Check(helpers.LoginThrottleKeys(constants.SubjectUser, "rara", "192.0.2.1"))
+	assert.NoError(t, err)
+	assert.Zero(t, retryAfter)
+}
+
+func TestForgotUserPasswordThrottleUnavailable(t *testing.T) {
+	_, h, _ := newTestHandlers()
+	h.Throttle = helpers.NewLoginThrottler(unavailableThrottles{})
+
+	rec := forgotUserPassword(t, h, "rara@gmail.com")
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Contains(t, rec.Body.String(), constants.ErrResetUnavailable)
+}
@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"errors"
+	applicationusecase "miniproject/applications/usecase"
+	"miniproject/entity"
+	"miniproject/middleware"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type selectionRunRequest struct {
+	Weights      *entity.RankingWeights `json:"weights"`
+	WaitlistSize *int                   `json:"waitlist_size"`
+	DryRun       bool                   `json:"dry_run"`
+}
+
+// SelectionRunController memberi peringkat semua formulir pada satu lowongan lalu menerima,
+// memasukkan ke daftar tunggu, atau menolak kandidat sesuai jumlah kursi lowongan.
+// Dengan dry_run hasilnya hanya ditampilkan tanpa mengubah status formulir.
+func (h *AdminHandler) SelectionRunController(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "ID lowongan magang tidak valid",
+		})
+	}
+
+	request := selectionRunRequest{}
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "Fail to parse request body",
+			"error":   err.Error(),
+		})
+	}
+
+	options := applicationusecase.SelectionOptions{
+		Weights:      request.Weights,
+		WaitlistSize: request.WaitlistSize,
+		DryRun:       request.DryRun,
+	}
+	run, err := h.Applications.RunSelection(uint(id), options, actorOf(middleware.GetPrincipal(c)))
+	if err != nil {
+		switch {
+		case errors.Is(err, applicationusecase.ErrListingNotFound):
+			return c.JSON(http.StatusNotFound, map[string]interface{}{
+				"message": err.Error(),
+			})
+		case errors.Is(err, applicationusecase.ErrNoSeats), errors.Is(err, applicationusecase.ErrInvalidWaitlistSize):
+			return c.JSON(http.StatusBadRequest, map[string]interface{}{
+				"message": err.Error(),
+			})
+		case errors.Is(err, applicationusecase.ErrStatusChanged):
+			return c.JSON(http.StatusConflict, map[string]interface{}{
+				"message": err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": "Gagal menjalankan seleksi kandidat",
+			"error":   err.Error(),
+		})
+	}
+
+	message := "Seleksi kandidat berhasil dijalankan"
+	if run.DryRun {
+		message = "Pratinjau seleksi kandidat, tidak ada status yang diubah"
+	}
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":   message,
+		"selection": run,
+	})
+}
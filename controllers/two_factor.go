@@ -0,0 +1,237 @@
+package controllers
+
+import (
+	"log"
+	"miniproject/constants"
+	"miniproject/entity"
+	"miniproject/helpers"
+	"miniproject/middleware"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const recoveryCodeCount = 10
+
+type twoFactorRequest struct {
+	ChallengeToken string `json:"challenge_token" form:"challenge_token"`
+	Code           string `json:"code" form:"code"`
+	RecoveryCode   string `json:"recovery_code" form:"recovery_code"`
+}
+
+func totpIssuer() string {
+	if issuer := os.Getenv("TOTPISSUER"); issuer != "" {
+		return issuer
+	}
+	return "PT Krisnadwipayana Magang"
+}
+
+// verifySecondFactor memvalidasi kode TOTP atau kode pemulihan milik admin. Kode yang berhasil
+// dipakai langsung ditandai dengan update bersyarat sehingga tidak bisa dipakai dua kali.
+func (h *AdminHandler) verifySecondFactor(admin *entity.Admin, code, recoveryCode string) (bool, error) {
+	if code != "" {
+		step, ok := helpers.ValidateTOTP(admin.TOTPSecret, code, time.Now(), admin.TOTPLastUsedStep)
+		if !ok {
+			return false, nil
+		}
+		used, err := h.Admins.UseTOTPStep(admin.ID, step)
+		if err != nil {
+			return false, err
+		}
+		admin.TOTPLastUsedStep = step
+		return used, nil
+	}
+
+	if recoveryCode != "" {
+		return h.Admins.UseRecoveryCode(admin.ID, helpers.HashToken(helpers.NormalizeRecoveryCode(recoveryCode)))
+	}
+
+	return false, nil
+}
+
+// EnrollTwoFactorController membuat secret TOTP baru dan mengembalikan URI provisioning untuk QR code.
+// 2FA belum aktif sampai admin mengonfirmasi dengan kode yang valid.
+func (h *AdminHandler) EnrollTwoFactorController(c echo.Context) error {
+	admin := middleware.GetPrincipal(c).Admin
+	if admin.TOTPEnabled {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "Two-factor authentication sudah aktif",
+		})
+	}
+
+	secret, err := helpers.GenerateTOTPSecret()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": "Gagal membuat secret 2FA",
+			"error":   err.Error(),
+		})
+	}
+
+	admin.TOTPSecret = secret
+	admin.TOTPLastUsedStep = 0
+	if err := h.Admins.Save(admin); err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": "Gagal menyimpan secret 2FA",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":          "Scan QR code lalu konfirmasi dengan kode dari aplikasi authenticator",
+		"secret":           secret,
+		"provisioning_uri": helpers.TOTPProvisioningURI(secret, totpIssuer(), admin.Username),
+	})
+}
+
+// ConfirmTwoFactorController mengaktifkan 2FA setelah kode pertama valid dan mengembalikan kode pemulihan.
+func (h *AdminHandler) ConfirmTwoFactorController(c echo.Context) error {
+	admin := middleware.GetPrincipal(c).Admin
+
+	request := twoFactorRequest{}
+	if err := c.Bind(&request); err != nil || request.Code == "" {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "Kode 2FA wajib diisi",
+		})
+	}
+	if admin.TOTPEnabled || admin.TOTPSecret == "" {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "Tidak ada pendaftaran 2FA yang menunggu konfirmasi",
+		})
+	}
+
+	ok, err := h.verifySecondFactor(admin, request.Code, "")
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": "Gagal memverifikasi kode 2FA",
+			"error":   err.Error(),
+		})
+	}
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "Kode 2FA tidak valid",
+		})
+	}
+
+	// Kode pemulihan hanya ditampilkan sekali; yang disimpan hanya hash-nya
+	codes, err := helpers.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": "Gagal mengaktifkan 2FA",
+			"error":   err.Error(),
+		})
+	}
+	codeHashes := make([]string, len(codes))
+	for i, code := range codes {
+		codeHashes[i] = helpers.HashToken(helpers.NormalizeRecoveryCode(code))
+	}
+	if err := h.Admins.EnableTOTP(admin.ID, codeHashes); err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": "Gagal mengaktifkan 2FA",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message":        "Two-factor authentication berhasil diaktifkan, simpan kode pemulihan di tempat aman",
+		"recovery_codes": codes,
+	})
+}
+
+// DisableTwoFactorController menonaktifkan 2FA setelah admin membuktikan kepemilikan dengan kode TOTP
+// atau kode pemulihan.
+func (h *AdminHandler) DisableTwoFactorController(c echo.Context) error {
+	admin := middleware.GetPrincipal(c).Admin
+
+	request := twoFactorRequest{}
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "Fail to parse request body",
+			"error":   err.Error(),
+		})
+	}
+	if !admin.TOTPEnabled {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "Two-factor authentication belum aktif",
+		})
+	}
+
+	ok, err := h.verifySecondFactor(admin, request.Code, request.RecoveryCode)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": "Gagal memverifikasi kode 2FA",
+			"error":   err.Error(),
+		})
+	}
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "Kode 2FA tidak valid",
+		})
+	}
+
+	if err := h.Admins.DisableTOTP(admin.ID); err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": "Gagal menonaktifkan 2FA",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Two-factor authentication berhasil dinonaktifkan",
+	})
+}
+
+// VerifyTwoFactorLoginController menukar challenge token dari LoginAdminController dan kode 2FA
+// dengan access token dan refresh token.
+func (h *AdminHandler) VerifyTwoFactorLoginController(c echo.Context) error {
+	request := twoFactorRequest{}
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "Fail to parse request body",
+			"error":   err.Error(),
+		})
+	}
+
+	adminID, err := middleware.ParseMFAChallengeToken(request.ChallengeToken)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+			"message": "Challenge token tidak valid atau sudah kedaluwarsa",
+		})
+	}
+
+	admin, err := h.Admins.FindByID(adminID)
+	if err != nil || !admin.TOTPEnabled {
+		return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+			"message": "Challenge token tidak valid atau sudah kedaluwarsa",
+		})
+	}
+
+	// Percobaan kode 2FA dibatasi dengan throttle yang sama seperti login password
+	throttleKeys := helpers.LoginThrottleKeys(constants.SubjectAdmin, admin.Username, c.RealIP())
+	retryAfter, err := h.Throttle.Check(throttleKeys)
+	if err != nil {
+		return loginUnavailable(c, err)
+	}
+	if retryAfter > 0 {
+		return tooManyLoginAttempts(c, retryAfter)
+	}
+
+	ok, err := h.verifySecondFactor(admin, request.Code, request.RecoveryCode)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": "Gagal memverifikasi kode 2FA",
+			"error":   err.Error(),
+		})
+	}
+	if !ok {
+		if err := h.Throttle.RecordFailure(throttleKeys); err != nil {
+			log.Println("gagal mencatat percobaan login:", err)
+		}
+		return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+			"message": "Kode 2FA tidak valid",
+		})
+	}
+
+	return h.adminLoginResponse(c, admin)
+}
@@ -1,36 +1,69 @@
 package controllers
 
 import (
+	"errors"
 	"fmt"
 	"log"
+	applicationusecase "miniproject/applications/usecase"
 	"miniproject/constants"
+	documentusecase "miniproject/documents/usecase"
 	"miniproject/entity"
-	"miniproject/infra/config"
+	"miniproject/helpers"
+	"miniproject/infra/storage"
+	listingusecase "miniproject/listings/usecase"
 	"miniproject/middleware"
+	"miniproject/repository"
 	"net/http"
 	"strconv"
-	"time"
 
 	"github.com/labstack/echo/v4"
-	"gorm.io/gorm"
 )
 
+// UserHandler menangani rute akun dan pendaftaran magang milik pengguna.
+type UserHandler struct {
+	Users          repository.UserRepository
+	PasswordResets repository.PasswordResetRepository
+	Listings       listingusecase.ListingUsecase
+	Applications   applicationusecase.ApplicationUsecase
+	Documents      documentusecase.DocumentUsecase
+	Auth           *middleware.Auth
+	Throttle       *helpers.LoginThrottler
+}
+
+func NewUserHandler(repos *repository.Repositories, store storage.Storage, auth *middleware.Auth, throttle *helpers.LoginThrottler) *UserHandler {
+	return &UserHandler{
+		Users:          repos.Users,
+		PasswordResets: repos.PasswordResets,
+		Listings:       listingusecase.NewListingUsecase(repos.Listings),
+		Applications:   applicationusecase.NewApplicationUsecase(repos.Applications, repos.Listings, repos.Offers),
+		Documents:      documentusecase.NewDocumentUsecase(repos.Documents, store),
+		Auth:           auth,
+		Throttle:       throttle,
+	}
+}
+
 // akun pengguna
 
+// userRequest adalah data akun pengguna dari request body. Hash password tidak pernah dikirim di
+// respons (json:"-"), sehingga password dari request dibaca melalui field terpisah.
+type userRequest struct {
+	entity.User
+	Password string `json:"password" form:"password"`
+}
+
 // Fungsi RegisterUser digunakan untuk mendaftarkan pengguna baru.
-func RegisterUser(c echo.Context) error {
-	user := entity.User{}
-	if err := c.Bind(&user); err != nil {
+func (h *UserHandler) RegisterUser(c echo.Context) error {
+	request := userRequest{}
+	if err := c.Bind(&request); err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]interface{}{
 			"message": "Invalid user data",
 			"error":   err.Error(),
 		})
 	}
+	user := request.User
 
 	// Cek apakah pengguna sudah terdaftar berdasarkan alamat email
-	var existingUser entity.User
-	err := config.DB.Where("email = ?", user.Email).First(&existingUser).Error
-	if err == nil {
+	if _, err := h.Users.FindByEmail(user.Email); err == nil {
 		return c.JSON(http.StatusBadRequest, map[string]interface{}{
 			"message": constants.ErrUserAlreadyExists,
 		})
@@ -38,15 +71,30 @@ func RegisterUser(c echo.Context) error {
 
 	// Atur peran pengguna menjadi 'user' (jika tidak sudah diset)
 	user.Role = "user"
+	user.EmailVerifiedAt = nil
 
-	// Jika pengguna belum terdaftar, simpan data pendaftaran ke dalam basis data
-	err = config.DB.Create(&user).Error
+	// Simpan password dalam bentuk hash, bukan plaintext
+	hashedPassword, err := helpers.HashPassword(request.Password)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
 			"message": constants.ErrFailedToRegister,
 			"error":   err.Error(),
 		})
 	}
+	user.Password = hashedPassword
+
+	// Jika pengguna belum terdaftar, simpan data pendaftaran ke dalam basis data
+	if err := h.Users.Create(&user); err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": constants.ErrFailedToRegister,
+			"error":   err.Error(),
+		})
+	}
+
+	// Akun baru harus memverifikasi email sebelum dapat mendaftar magang
+	if err := sendVerificationEmail(&user); err != nil {
+		log.Println("gagal mengirim email verifikasi:", err)
+	}
 
 	// Mengirim respons HTTP berhasil setelah pengguna berhasil didaftarkan
 	return c.JSON(http.StatusOK, map[string]interface{}{
@@ -56,26 +104,68 @@ func RegisterUser(c echo.Context) error {
 }
 
 // Fungsi LoginUserController digunakan untuk mengautentikasi pengguna dan memberikan token akses jika berhasil.
-func LoginUserController(c echo.Context) error {
+func (h *UserHandler) LoginUserController(c echo.Context) error {
 	// Membuat instance pengguna dan mengikat data dari permintaan HTTP
-	user := entity.User{}
-	if err := c.Bind(&user); err != nil {
+	request := userRequest{}
+	if err := c.Bind(&request); err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]interface{}{
 			"message": "Fail to parse request body",
 			"error":   err.Error(),
 		})
 	}
 
-	// Mencari pengguna dalam basis data berdasarkan email dan kata sandi
-	err := config.DB.Where("Username = ? AND password = ?", user.Username, user.Password).First(&user).Error
+	// Tolak sementara jika username atau IP sedang dikunci / masih dalam jeda progresif
+	user := request.User
+	throttleKeys := helpers.LoginThrottleKeys(constants.SubjectUser, user.Username, c.RealIP())
+	retryAfter, err := h.Throttle.Check(throttleKeys)
 	if err != nil {
+		return loginUnavailable(c, err)
+	}
+	if retryAfter > 0 {
+		return tooManyLoginAttempts(c, retryAfter)
+	}
+
+	// Mencari pengguna dalam basis data berdasarkan username, lalu verifikasi kata sandi
+	password := request.Password
+	match, needsRehash := false, false
+	if existingUser, err := h.Users.FindByUsername(user.Username); err == nil {
+		user = *existingUser
+		match, needsRehash = helpers.VerifyPassword(user.Password, password)
+	} else {
+		helpers.DummyVerifyPassword(password)
+	}
+	if !match {
+		if err := h.Throttle.RecordFailure(throttleKeys); err != nil {
+			log.Println("gagal mencatat percobaan login:", err)
+		}
 		return c.JSON(http.StatusUnauthorized, map[string]interface{}{
-			"message": constants.ErrFailedToLogIn,
+			"message": constants.ErrInvalidCredentials,
+		})
+	}
+	if err := h.Throttle.Reset(throttleKeys[0]); err != nil {
+		log.Println("gagal mereset percobaan login:", err)
+	}
+
+	// Password lama (plaintext) di-hash ulang secara otomatis saat login berhasil
+	if needsRehash {
+		if hashedPassword, err := helpers.HashPassword(password); err == nil {
+			user.Password = hashedPassword
+			user.PasswordResetRequired = false
+			if err := h.Users.Save(&user); err != nil {
+				log.Println("gagal menyimpan hash password baru:", err)
+			}
+		}
+	}
+
+	token, err := middleware.CreateToken(user.ID, user.Username, constants.SubjectUser, user.Role)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": constants.ErrTokenCreationFailed,
 			"error":   err.Error(),
 		})
 	}
 
-	token, err := middleware.CreateToken(user.ID, user.Username)
+	refreshToken, err := h.Auth.IssueRefreshToken(constants.SubjectUser, user.ID, "")
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
 			"message": constants.ErrTokenCreationFailed,
@@ -84,10 +174,11 @@ func LoginUserController(c echo.Context) error {
 	}
 
 	UserResponse := entity.UserResponse{
-		ID:    user.ID,
-		Username: user.Username,
-		Email: user.Email,
-		Token: token,
+		ID:           user.ID,
+		Username:     user.Username,
+		Email:        user.Email,
+		Token:        token,
+		RefreshToken: refreshToken,
 	}
 
 	return c.JSON(http.StatusOK, map[string]interface{}{
@@ -96,20 +187,10 @@ func LoginUserController(c echo.Context) error {
 	})
 }
 
-// GetAllUsers digunakan untuk mendapatkan semua data pengguna.
-func GetAllUsers(c echo.Context) error {
-	UserID, Username := middleware.ExtractToken(c)
-	user := entity.User{}
-	err := config.DB.Where("Username = ? AND ID = ?", Username, UserID).First(&user).Error
+// GetAllUsers digunakan admin untuk mendapatkan semua data pengguna.
+func (h *UserHandler) GetAllUsers(c echo.Context) error {
+	users, err := h.Users.FindAll()
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, map[string]interface{}{
-			"message": constants.ErrFailedToLogIn,
-			"error":   err.Error(),
-		})
-	}
-
-	var users []entity.User
-	if err := config.DB.Find(&users).Error; err != nil {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"message": "Failed to retrieve users"})
 	}
 	return c.JSON(http.StatusOK, map[string]interface{}{
@@ -119,25 +200,15 @@ func GetAllUsers(c echo.Context) error {
 }
 
 // GetUserByID digunakan untuk mendapatkan data pengguna berdasarkan ID.
-func GetUserByID(c echo.Context) error {
-	UserID, Username := middleware.ExtractToken(c)
-	User := entity.User{}
-	err := config.DB.Where("Username = ? AND ID = ?", Username, UserID).First(&User).Error
-	if err != nil {
-		return c.JSON(http.StatusUnauthorized, map[string]interface{}{
-			"message": constants.ErrFailedToLogIn,
-			"error":   err.Error(),
-		})
-	}
-
+func (h *UserHandler) GetUserByID(c echo.Context) error {
 	idStr := c.Param("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"message": "Invalid user ID"})
 	}
 
-	var user entity.User
-	if err := config.DB.First(&user, id).Error; err != nil {
+	user, err := h.Users.FindByID(uint(id))
+	if err != nil {
 		return c.JSON(http.StatusNotFound, map[string]string{"message": "User not found"})
 	}
 
@@ -148,17 +219,7 @@ func GetUserByID(c echo.Context) error {
 }
 
 // Fungsi UpdateUserByID digunakan untuk memperbarui data pengguna berdasarkan ID.
-func UpdateUserByID(c echo.Context) error {
-	UserID, Username := middleware.ExtractToken(c)
-	User := entity.User{}
-	err := config.DB.Where("Username = ? AND ID = ?", Username, UserID).First(&User).Error
-	if err != nil {
-		return c.JSON(http.StatusUnauthorized, map[string]interface{}{
-			"message": constants.ErrFailedToLogIn,
-			"error":   err.Error(),
-		})
-	}
-
+func (h *UserHandler) UpdateUserByID(c echo.Context) error {
 	// Mendapatkan ID pengguna dari parameter rute
 	IdStr := c.Param("id")
 	Id, err := strconv.Atoi(IdStr)
@@ -167,24 +228,43 @@ func UpdateUserByID(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid ID")
 	}
 
+	// Hanya pemilik akun atau admin yang boleh memperbarui data pengguna
+	if !middleware.OwnerOrAdmin(middleware.GetPrincipal(c), uint(Id)) {
+		return middleware.Forbidden(c)
+	}
+
 	// Membuat instance baru dari entitas pengguna dan mengikat data dari permintaan HTTP
-	user := new(entity.User)
-	if err := c.Bind(user); err != nil {
+	request := new(userRequest)
+	if err := c.Bind(request); err != nil {
 		// Mengirim respons HTTP jika terjadi kesalahan dalam mengikat data pengguna
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
+	user := &request.User
 
 	// Mencari pengguna yang ada dalam basis data berdasarkan ID
-	var existingUser entity.User
-	if err := config.DB.First(&existingUser, Id).Error; err != nil {
+	existingUser, err := h.Users.FindByID(uint(Id))
+	if err != nil {
 		// Mengirim respons HTTP jika pengguna tidak ditemukan
 		return echo.NewHTTPError(http.StatusNotFound, "User not found")
 	}
 
 	// Memperbarui data pengguna yang ada dengan data baru
+	emailChanged := existingUser.Email != user.Email
 	existingUser.Username = user.Username
 	existingUser.Email = user.Email
-	existingUser.Password = user.Password
+	if emailChanged {
+		// Email baru wajib diverifikasi ulang
+		existingUser.EmailVerifiedAt = nil
+	}
+	passwordChanged := request.Password != ""
+	if passwordChanged {
+		hashedPassword, err := helpers.HashPassword(request.Password)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+		existingUser.Password = hashedPassword
+		existingUser.PasswordResetRequired = false
+	}
 	existingUser.Gender = user.Gender
 	existingUser.PhoneNumber = user.PhoneNumber
 	existingUser.UniversityName = user.UniversityName
@@ -192,11 +272,24 @@ func UpdateUserByID(c echo.Context) error {
 	existingUser.Major = user.Major
 
 	// Menyimpan perubahan data pengguna ke dalam basis data
-	if err := config.DB.Save(&existingUser).Error; err != nil {
+	if err := h.Users.Save(existingUser); err != nil {
 		// Mengirim respons HTTP jika terjadi kesalahan saat menyimpan perubahan
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
+	// Password baru membatalkan semua sesi yang sudah diterbitkan
+	if passwordChanged {
+		if err := h.Auth.RevokeSubjectSessions(constants.SubjectUser, existingUser.ID); err != nil {
+			return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+		}
+	}
+
+	if emailChanged {
+		if err := sendVerificationEmail(existingUser); err != nil {
+			log.Println("gagal mengirim email verifikasi:", err)
+		}
+	}
+
 	// Mengirim respons HTTP berhasil setelah pengguna diperbarui
 	return c.JSON(http.StatusOK, map[string]interface{}{
 		"message": "Success update user",
@@ -205,17 +298,7 @@ func UpdateUserByID(c echo.Context) error {
 }
 
 // Menghapus data user berdasarkan ID
-func DeleteUser(c echo.Context) error {
-	UserID, Username := middleware.ExtractToken(c)
-	user := entity.User{}
-	err := config.DB.Where("Username = ? AND ID = ?", Username, UserID).First(&user).Error
-	if err != nil {
-		return c.JSON(http.StatusUnauthorized, map[string]interface{}{
-			"message": constants.ErrFailedToLogIn,
-			"error":   err.Error(),
-		})
-	}
-
+func (h *UserHandler) DeleteUser(c echo.Context) error {
 	IdStr := c.Param("id")
 	Id, err := strconv.Atoi(IdStr)
 	// Mengirim respons HTTP jika ID pengguna tidak valid
@@ -223,15 +306,24 @@ func DeleteUser(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid User ID")
 	}
 
-	var User entity.User
+	// Hanya pemilik akun atau admin yang boleh menghapus pengguna
+	if !middleware.OwnerOrAdmin(middleware.GetPrincipal(c), uint(Id)) {
+		return middleware.Forbidden(c)
+	}
+
 	// Mengirim respons HTTP jika pengguna tidak ditemukan
-	if err := config.DB.First(&User, Id).Error; err != nil {
+	User, err := h.Users.FindByID(uint(Id))
+	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "User Not Found")
 	}
 	// Mengirim respons HTTP jika terjadi kesalahan saat menghapus pengguna
-	if err := config.DB.Delete(&User).Error; err != nil {
+	if err := h.Users.Delete(User); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
+	// Akun yang dihapus tidak boleh lagi memakai token yang sudah diterbitkan
+	if err := h.Auth.RevokeSubjectSessions(constants.SubjectUser, User.ID); err != nil {
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
 
 	return c.JSON(http.StatusOK, map[string]interface{}{
 		"message": "success delete user",
@@ -241,43 +333,13 @@ func DeleteUser(c echo.Context) error {
 
 //  internship user
 
-// GetInternshipListings digunakan untuk mendapatkan daftar lowongan magang
-func GetInternshipListings(c echo.Context) error {
-	UserID, Username := middleware.ExtractToken(c)
-	User := entity.User{}
-	err := config.DB.Where("Username = ? AND ID = ?", Username, UserID).First(&User).Error
-	if err != nil {
-		return c.JSON(http.StatusUnauthorized, map[string]interface{}{
-			"message": constants.ErrFailedToLogIn,
-			"error":   err.Error(),
-		})
-	}
-
-	var internshipListings []entity.Internship_Listing
-
-	// Dapatkan semua daftar lowongan magang dari basis data
-	if err := config.DB.Find(&internshipListings).Error; err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
-			"message": "Gagal mengambil daftar magang",
-			"error":   err.Error(),
-		})
-	}
-
-	return c.JSON(http.StatusOK, map[string]interface{}{
-		"message":  "Daftar magang Terbaru", //diganti
-		"listings": internshipListings,
-	})
-}
-
 // ApplyForInternship ini digunakan untuk mengirimkan aplikasi pendaftaran magang
-func ApplyForInternship(c echo.Context) error {
-	UserID, Username := middleware.ExtractToken(c)
-	User := entity.User{}
-	err := config.DB.Where("Username = ? AND ID = ?", Username, UserID).First(&User).Error
-	if err != nil {
-		return c.JSON(http.StatusUnauthorized, map[string]interface{}{
-			"message": constants.ErrFailedToLogIn,
-			"error":   err.Error(),
+func (h *UserHandler) ApplyForInternship(c echo.Context) error {
+	// Hanya akun dengan email terverifikasi yang boleh mendaftar magang
+	principal := middleware.GetPrincipal(c)
+	if principal == nil || principal.User == nil || principal.User.EmailVerifiedAt == nil {
+		return c.JSON(http.StatusForbidden, map[string]interface{}{
+			"message": constants.ErrEmailNotVerified,
 		})
 	}
 
@@ -290,126 +352,46 @@ func ApplyForInternship(c echo.Context) error {
 			"error":   err.Error(),
 		})
 	}
-
-	// Mencari ID penawaran magang berdasarkan judul yang dipilih
-	selectedTitle := formData.SelectedTitle
-	var selectedListingID uint
-	// sesuaikan cara mengambil data dari database
-	var listing entity.Internship_Listing
-	if err := config.DB.Where("title = ?", selectedTitle).First(&listing).Error; err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"message": "Penawaran magang tidak ditemukan",
-		})
-	}
-	selectedListingID = listing.ID
-
-	// Validasi data
-	invalidData := make(map[string]string)
-	if formData.Nim == "" {
-		invalidData["nim"] = "Nim is required"
-	}
-	if formData.GPA <= 0 {
-		invalidData["gpa"] = "GPA must be greater than 0"
-	}
-	if formData.EducationLevel == "" {
-		invalidData["education_level"] = "Education level is required"
-	}
-	if formData.Username == "" {
-		invalidData["username"] = "Username is required"
-	}
-	if formData.UserEmail == "" {
-		invalidData["user_email"] = "User email is required"
-	}
-
-	if len(invalidData) > 0 {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"message":     "Data formulir tidak valid",
-			"invalidData": invalidData,
-		})
-	}
-
-	// Simpan aplikasi ke dalam database
-	application := entity.Internship_ApplicationForm{
-		Model:               gorm.Model{},
-		CV:                  formData.CV,
-		Nim:                 formData.Nim,
-		GPA:                 formData.GPA,
-		EducationLevel:      formData.EducationLevel,
-		UserID:              formData.UserID,
-		Status:              "",
-		UserEmail:           formData.UserEmail,
-		Username:            formData.Username,
-		SelectedTitle:       selectedTitle,
-		InternshipListingID: selectedListingID,
-		// Selected_Candidates: formData.Selected_Candidates,
+	// Simpan aplikasi atas nama akun yang sedang login, kurangi kuota, dan catat kandidat
+	application, err := h.Applications.Apply(principal.User, &formData)
+	if err != nil {
+		return applyError(c, err)
 	}
 	log.Println("application", application)
 
-	// Dapatkan penawaran magang berdasarkan selectedListingID
-	var internshipListing entity.Internship_Listing
-	if err := config.DB.First(&internshipListing, selectedListingID).Error; err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"message": "Penawaran magang tidak ditemukan",
-		})
-	}
-
-	if err := config.DB.Create(&application).Error; err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
-			"message": "Gagal memproses pendaftaran magang",
-			"error":   err.Error(),
-		})
-	}
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Pendaftaran magang berhasil disimpan",
+	})
+}
 
-	// Kurangi kuota penawaran magang
-	if internshipListing.Quota <= 0 {
+// applyError memetakan error pendaftaran dan draft formulir ke respons HTTP.
+func applyError(c echo.Context, err error) error {
+	var validationErr *applicationusecase.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
 		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"message": "Kuota pendaftaran magang sudah penuh",
+			"message":     validationErr.Error(),
+			"invalidData": validationErr.Fields,
 		})
-	}
-	internshipListing.Quota--
-	if err := config.DB.Save(&internshipListing).Error; err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
-			"message": "Gagal memproses pendaftaran magang",
-			"error":   err.Error(),
+	case errors.Is(err, applicationusecase.ErrAlreadyApplied), errors.Is(err, applicationusecase.ErrTooManyActive),
+		errors.Is(err, applicationusecase.ErrNotDraft):
+		return c.JSON(http.StatusConflict, map[string]interface{}{
+			"message": err.Error(),
 		})
-	}
-
-	// Membuat dan menyimpan Selected_Candidate
-	selectedCandidate := entity.Selected_Candidate{
-		Model: gorm.Model{
-			ID:        selectedListingID,
-			CreatedAt: time.Time{},
-			UpdatedAt: time.Time{},
-			DeletedAt: gorm.DeletedAt{},
-		},
-		InternshipApplicationFormID: application.ID,
-		InternshipApplicationForm:   application,
-	}
-
-	if err := config.DB.Create(&selectedCandidate).Error; err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
-			"message": "Gagal memproses pendaftaran magang",
-			"error":   err.Error(),
+	case errors.Is(err, applicationusecase.ErrListingNotFound), errors.Is(err, applicationusecase.ErrQuotaFull),
+		errors.Is(err, applicationusecase.ErrListingClosed):
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": err.Error(),
 		})
 	}
-
-	return c.JSON(http.StatusOK, map[string]interface{}{
-		"message": "Pendaftaran magang berhasil disimpan",
+	return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+		"message": "Gagal memproses pendaftaran magang",
+		"error":   err.Error(),
 	})
 }
 
 // CancelApplication digunakan untuk membatalkan formulir aplikasi berdasarkan ID.
-func CancelApplication(c echo.Context) error {
-	UserID, Username := middleware.ExtractToken(c)
-	User := entity.User{}
-	err := config.DB.Where("Username = ? AND ID = ?", Username, UserID).First(&User).Error
-	if err != nil {
-		return c.JSON(http.StatusUnauthorized, map[string]interface{}{
-			"message": constants.ErrFailedToLogIn,
-			"error":   err.Error(),
-		})
-	}
-
+func (h *UserHandler) CancelApplication(c echo.Context) error {
 	// Mendapatkan ID formulir aplikasi yang ingin dibatalkan
 	idParam := c.Param("id")
 
@@ -422,40 +404,31 @@ func CancelApplication(c echo.Context) error {
 	}
 
 	// Cari formulir aplikasi berdasarkan ID
-	var application entity.Internship_ApplicationForm
-	if err := config.DB.Where("id = ?", id).First(&application).Error; err != nil {
+	application, err := h.Applications.GetApplication(uint(id))
+	if err != nil {
 		return c.JSON(http.StatusNotFound, map[string]interface{}{
 			"message": "Formulir aplikasi tidak ditemukan",
 		})
 	}
 
-	// Periksa apakah formulir aplikasi sudah dibatalkan sebelumnya
-	if application.IsCanceled {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"message": "Formulir aplikasi sudah dibatalkan sebelumnya",
-		})
-	}
-
-	// Mengubah status formulir menjadi "Dibatalkan" dan menyimpan perubahan ke database
-	application.Status = "Dibatalkan"
-	application.IsCanceled = true
-	if err := config.DB.Save(&application).Error; err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
-			"message": "Gagal membatalkan formulir aplikasi",
-			"error":   err.Error(),
-		})
-	}
-
-	// Mengembalikan kuota penawaran magang
-	var internshipListing entity.Internship_Listing
-	if err := config.DB.First(&internshipListing, application.InternshipListingID).Error; err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]interface{}{
-			"message": "Penawaran magang tidak ditemukan",
-		})
-	}
-
-	internshipListing.Quota++
-	if err := config.DB.Save(&internshipListing).Error; err != nil {
+	// Hanya pemilik formulir atau admin yang boleh membatalkan aplikasi
+	principal := middleware.GetPrincipal(c)
+	if !middleware.OwnerOrAdmin(principal, application.UserID) {
+		return middleware.Forbidden(c)
+	}
+
+	// Ubah status formulir dan kembalikan kuota penawaran magang
+	if err := h.Applications.Cancel(application, actorOf(principal)); err != nil {
+		switch {
+		case errors.Is(err, applicationusecase.ErrAlreadyCanceled):
+			return c.JSON(http.StatusBadRequest, map[string]interface{}{
+				"message": err.Error(),
+			})
+		case errors.Is(err, applicationusecase.ErrInvalidTransition), errors.Is(err, applicationusecase.ErrStatusChanged):
+			return c.JSON(http.StatusConflict, map[string]interface{}{
+				"message": err.Error(),
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
 			"message": "Gagal memproses pembatalan formulir aplikasi",
 			"error":   err.Error(),
@@ -468,17 +441,7 @@ func CancelApplication(c echo.Context) error {
 }
 
 // GetApplicationStatus digunakan untuk mendapatkan status formulir aplikasi berdasarkan ID.
-func GetApplicationStatus(c echo.Context) error {
-	UserID, Username := middleware.ExtractToken(c)
-	User := entity.User{}
-	err := config.DB.Where("Username = ? AND ID = ?", Username, UserID).First(&User).Error
-	if err != nil {
-		return c.JSON(http.StatusUnauthorized, map[string]interface{}{
-			"message": constants.ErrFailedToLogIn,
-			"error":   err.Error(),
-		})
-	}
-
+func (h *UserHandler) GetApplicationStatus(c echo.Context) error {
 	// Mendapatkan ID dari parameter URL
 	idParam := c.Param("id")
 
@@ -491,13 +454,18 @@ func GetApplicationStatus(c echo.Context) error {
 	}
 
 	// Cari form aplikasi berdasarkan ID
-	var application entity.Internship_ApplicationForm
-	if err := config.DB.Where("id = ?", id).First(&application).Error; err != nil {
+	application, err := h.Applications.GetApplication(uint(id))
+	if err != nil {
 		return c.JSON(http.StatusNotFound, map[string]interface{}{
 			"message": "Form aplikasi tidak ditemukan",
 		})
 	}
 
+	// Hanya pemilik formulir atau admin yang boleh melihat status aplikasi
+	if !middleware.OwnerOrAdmin(middleware.GetPrincipal(c), application.UserID) {
+		return middleware.Forbidden(c)
+	}
+
 	// Anda dapat mengakses status aplikasi melalui application.Status
 	return c.JSON(http.StatusOK, map[string]interface{}{
 		"message": "Status form aplikasi",
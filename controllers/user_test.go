@@ -1,16 +1,52 @@
 package controllers
 
 import (
+	"errors"
+	applicationusecase "miniproject/applications/usecase"
+	"miniproject/constants"
+	"miniproject/entity"
+	"miniproject/helpers"
+	"miniproject/middleware"
+	"miniproject/repository"
+	"miniproject/repository/memory"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 )
 
+// newTestHandlers menyiapkan handler dengan repository in-memory sehingga test tidak membutuhkan MySQL.
+// Test yang mengunggah dokumen memasang penyimpanan sendiri lewat useTestStorage.
+func newTestHandlers() (*repository.Repositories, *UserHandler, *AdminHandler) {
+	repos := memory.NewRepositories()
+	auth := middleware.NewAuth(repos.Users, repos.Admins, repos.Sessions)
+	throttle := helpers.NewLoginThrottler(repos.LoginThrottles)
+	return repos, NewUserHandler(repos, nil, auth, throttle), NewAdminHandler(repos, nil, auth, throttle)
+}
+
+func seedUser(t *testing.T, repos *repository.Repositories, username, email, password string, verified bool) *entity.User {
+	hashedPassword, err := helpers.HashPassword(password)
+	assert.NoError(t, err)
+	user := &entity.User{Username: username, Email: email, Password: hashedPassword, Role: constants.RoleUser}
+	if verified {
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+	}
+	assert.NoError(t, repos.Users.Create(user))
+	return user
+}
+
+func userPrincipal(user *entity.User) *middleware.Principal {
+	return &middleware.Principal{ID: user.ID, Username: user.Username, Type: constants.SubjectUser, Role: constants.RoleUser, User: user}
+}
+
 func TestRegisterUser(t *testing.T) {
+	_, h, _ := newTestHandlers()
 	e := echo.New()
 	payload := `{
         "Username": "rara",
@@ -22,13 +58,15 @@ func TestRegisterUser(t *testing.T) {
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
 
-	if assert.NoError(t, RegisterUser(c)) {
+	if assert.NoError(t, h.RegisterUser(c)) {
 		assert.Equal(t, http.StatusOK, rec.Code)
 		assert.Contains(t, rec.Body.String(), "Success create new user")
 	}
 }
 
 func TestRegisterUserError(t *testing.T) {
+	repos, h, _ := newTestHandlers()
+	seedUser(t, repos, "rara", "rara@gmail.com", "rara12", false)
 	e := echo.New()
 	payload := `{
         "Username": "rara",
@@ -40,41 +78,94 @@ func TestRegisterUserError(t *testing.T) {
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
 
-	if assert.Error(t, RegisterUser(c)) {
-		assert.Equal(t, http.StatusConflict, rec.Code)
-		assert.Contains(t, rec.Body.String(), "User already exists")
+	if assert.NoError(t, h.RegisterUser(c)) {
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), constants.ErrUserAlreadyExists)
 	}
 }
 
 func TestLoginUserController(t *testing.T) {
+	repos, h, _ := newTestHandlers()
+	seedUser(t, repos, "rara", "rara@gmail.com", "rara12", true)
 	e := echo.New()
 	payload := `{
         "Username": "rara",
-        "Email": "rara@gmail.com",
+        "Password": "rara12"
     }`
 	req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(payload))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
 
-	if assert.NoError(t, LoginUserController(c)) {
+	if assert.NoError(t, h.LoginUserController(c)) {
 		assert.Equal(t, http.StatusOK, rec.Code)
 		assert.Contains(t, rec.Body.String(), "Success login")
+		assert.Contains(t, rec.Body.String(), "refresh_token")
+	}
+}
+
+func TestLoginUserControllerInvalidPassword(t *testing.T) {
+	repos, h, _ := newTestHandlers()
+	seedUser(t, repos, "rara", "rara@gmail.com", "rara12", true)
+	e := echo.New()
+	payload := `{"Username": "rara", "Password": "salah"}`
+	req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(payload))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	if assert.NoError(t, h.LoginUserController(c)) {
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Contains(t, rec.Body.String(), constants.ErrInvalidCredentials)
+	}
+}
+
+// unavailableThrottles mensimulasikan repository throttle yang tidak dapat diakses.
+type unavailableThrottles struct {
+	repository.LoginThrottleRepository
+}
+
+func (unavailableThrottles) FindByIdentifiers(identifiers []string) ([]entity.LoginThrottle, error) {
+	return nil, errors.New("database tidak tersedia")
+}
+
+func TestLoginUserControllerThrottleUnavailable(t *testing.T) {
+	repos, h, _ := newTestHandlers()
+	seedUser(t, repos, "rara", "rara@gmail.com", "rara12", true)
+	h.Throttle = helpers.NewLoginThrottler(unavailableThrottles{})
+	e := echo.New()
+	payload := `{"Username": "rara", "Password": "rara12"}`
+	req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(payload))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	// Throttle yang tidak dapat diperiksa menolak login, bukan menonaktifkan perlindungan
+	if assert.NoError(t, h.LoginUserController(c)) {
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		assert.Contains(t, rec.Body.String(), constants.ErrLoginUnavailable)
 	}
 }
 
 func TestGetAllUsers(t *testing.T) {
+	repos, h, _ := newTestHandlers()
+	seedUser(t, repos, "rara", "rara@gmail.com", "rara12", true)
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/users", nil)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
 
-	if assert.NoError(t, GetAllUsers(c)) {
+	if assert.NoError(t, h.GetAllUsers(c)) {
 		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "rara@gmail.com")
+		// Hash password tidak boleh ikut terkirim di respons
+		assert.NotContains(t, rec.Body.String(), "$2a$")
 	}
 }
 
 func TestGetUserByID(t *testing.T) {
+	repos, h, _ := newTestHandlers()
+	seedUser(t, repos, "rara", "rara@gmail.com", "rara12", true)
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/users/1", nil)
 	rec := httptest.NewRecorder()
@@ -82,11 +173,12 @@ func TestGetUserByID(t *testing.T) {
 	c.SetParamNames("id")
 	c.SetParamValues("1")
 
-	if assert.NoError(t, GetUserByID(c)) {
+	if assert.NoError(t, h.GetUserByID(c)) {
 		assert.Equal(t, http.StatusOK, rec.Code)
 	}
 }
 func TestGetUserByIDError(t *testing.T) {
+	_, h, _ := newTestHandlers()
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/users/999", nil)
 	rec := httptest.NewRecorder()
@@ -94,13 +186,17 @@ func TestGetUserByIDError(t *testing.T) {
 	c.SetParamNames("id")
 	c.SetParamValues("999")
 
-	if assert.Error(t, GetUserByID(c)) {
+	if assert.NoError(t, h.GetUserByID(c)) {
 		assert.Equal(t, http.StatusNotFound, rec.Code)
 		assert.Contains(t, rec.Body.String(), "User not found")
 	}
 }
 
 func TestUpdateUserByID(t *testing.T) {
+	repos, h, _ := newTestHandlers()
+	user := seedUser(t, repos, "rara", "rara@gmail.com", "rara12", true)
+	refreshToken, err := h.Auth.IssueRefreshToken(constants.SubjectUser, user.ID, "")
+	assert.NoError(t, err)
 	e := echo.New()
 	payload := `{
         "Username": "raras",
@@ -113,13 +209,28 @@ func TestUpdateUserByID(t *testing.T) {
 	c := e.NewContext(req, rec)
 	c.SetParamNames("id")
 	c.SetParamValues("1")
+	middleware.SetPrincipal(c, userPrincipal(user))
 
-	if assert.NoError(t, UpdateUserByID(c)) {
+	if assert.NoError(t, h.UpdateUserByID(c)) {
 		assert.Equal(t, http.StatusOK, rec.Code)
 		assert.Contains(t, rec.Body.String(), "Success update user")
 	}
+
+	updated, err := repos.Users.FindByID(user.ID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "raras@gmail.com", updated.Email)
+		// Email baru wajib diverifikasi ulang
+		assert.Nil(t, updated.EmailVerifiedAt)
+		match, _ := helpers.VerifyPassword(updated.Password, "raras12")
+		assert.True(t, match)
+	}
+
+	// Password baru membatalkan refresh token yang sudah diterbitkan
+	_, _, err = h.Auth.RotateRefreshToken(refreshToken)
+	assert.Error(t, err)
 }
 func TestUpdateUserByIDError(t *testing.T) {
+	_, h, _ := newTestHandlers()
 	e := echo.New()
 	payload := `{
         "Username": "updateduser",
@@ -132,75 +243,177 @@ func TestUpdateUserByIDError(t *testing.T) {
 	c := e.NewContext(req, rec)
 	c.SetParamNames("id")
 	c.SetParamValues("999")
+	middleware.SetPrincipal(c, &middleware.Principal{ID: 1, Username: "caca", Type: constants.SubjectAdmin, Role: constants.RoleAdmin})
 
-	if assert.Error(t, UpdateUserByID(c)) {
-		assert.Equal(t, http.StatusNotFound, rec.Code)
-		assert.Contains(t, rec.Body.String(), "User not found")
+	err := h.UpdateUserByID(c)
+	if assert.Error(t, err) {
+		assert.Equal(t, http.StatusNotFound, err.(*echo.HTTPError).Code)
+		assert.Contains(t, err.Error(), "User not found")
 	}
 }
 
 func TestDeleteUser(t *testing.T) {
+	repos, h, _ := newTestHandlers()
+	user := seedUser(t, repos, "rara", "rara@gmail.com", "rara12", true)
+	refreshToken, err := h.Auth.IssueRefreshToken(constants.SubjectUser, user.ID, "")
+	assert.NoError(t, err)
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodDelete, "/users/1", nil)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
 	c.SetParamNames("id")
 	c.SetParamValues("1")
+	middleware.SetPrincipal(c, userPrincipal(user))
 
-	if assert.NoError(t, DeleteUser(c)) {
+	if assert.NoError(t, h.DeleteUser(c)) {
 		assert.Equal(t, http.StatusOK, rec.Code)
 		assert.Contains(t, rec.Body.String(), "success delete user")
 	}
+
+	_, err = repos.Users.FindByID(user.ID)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	// Akun yang dihapus tidak dapat lagi memperbarui sesinya
+	_, _, err = h.Auth.RotateRefreshToken(refreshToken)
+	assert.Error(t, err)
 }
 
 func TestGetInternshipListings(t *testing.T) {
+	repos, h, _ := newTestHandlers()
+	assert.NoError(t, repos.Listings.Create(&entity.Internship_Listing{Title: "Software Engineer", Quota: 2, Status: constants.ListingPublished}))
+	assert.NoError(t, repos.Listings.Create(&entity.Internship_Listing{Title: "Data Analyst", Quota: 2}))
+	deadline := time.Now().Add(-time.Hour)
+	assert.NoError(t, repos.Listings.Create(&entity.Internship_Listing{Title: "UI Designer", Quota: 2, Status: constants.ListingPublished, ApplicationDeadline: &deadline}))
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/internship-listings", nil)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
 
-	if assert.NoError(t, GetInternshipListings(c)) {
+	if assert.NoError(t, h.GetInternshipListings(c)) {
 		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), "Software Engineer")
+		assert.NotContains(t, rec.Body.String(), "Data Analyst")
+		assert.NotContains(t, rec.Body.String(), "UI Designer")
 	}
 }
 
 func TestApplyForInternship(t *testing.T) {
+	repos, h, _ := newTestHandlers()
+	user := seedUser(t, repos, "raras", "raras@gmail.com", "raras12", true)
+	listing := &entity.Internship_Listing{Title: "Software Engineer", Quota: 2, Status: constants.ListingPublished}
+	assert.NoError(t, repos.Listings.Create(listing))
 	e := echo.New()
 	payload := `{
-        "SelectedTitle": "Software Engineer ",
-        "CV": "path/to/cv.pdf",
-        "Nim": "123456",
-        "GPA": 3.5,
-        "EducationLevel": "S1",
-        "UserID": 1,
-        "UserEmail": "raras@gmail.com",
-        "Username": "raras"
+        "internshiplistingID": ` + strconv.Itoa(int(listing.ID)) + `,
+        "selected_title": "Judul Lama",
+        "cv": "path/to/cv.pdf",
+        "nim": "123456",
+        "gpa": 3.5,
+        "education_level": "S1",
+        "user_id": 99,
+        "user_email": "lain@gmail.com",
+        "username": "lain"
     }`
 	req := httptest.NewRequest(http.MethodPost, "/apply-for-internship", strings.NewReader(payload))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
+	middleware.SetPrincipal(c, userPrincipal(user))
 
-	if assert.NoError(t, ApplyForInternship(c)) {
+	if assert.NoError(t, h.ApplyForInternship(c)) {
 		assert.Equal(t, http.StatusOK, rec.Code)
 		assert.Contains(t, rec.Body.String(), "Pendaftaran magang berhasil disimpan")
 	}
+
+	listing, err := repos.Listings.FindByID(listing.ID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, listing.Quota)
+	}
+
+	// Identitas pendaftar diambil dari akun yang login, bukan dari request body
+	applications, err := repos.Applications.FindAll()
+	if assert.NoError(t, err) && assert.Len(t, applications, 1) {
+		assert.Equal(t, user.ID, applications[0].UserID)
+		assert.Equal(t, "raras@gmail.com", applications[0].UserEmail)
+		assert.Equal(t, "raras", applications[0].Username)
+		assert.Equal(t, "Software Engineer", applications[0].SelectedTitle)
+	}
+}
+
+func TestApplyForInternshipDuplicate(t *testing.T) {
+	repos, h, _ := newTestHandlers()
+	user := seedUser(t, repos, "raras", "raras@gmail.com", "raras12", true)
+	listing := &entity.Internship_Listing{Title: "Software Engineer", Quota: 5, Status: constants.ListingPublished}
+	assert.NoError(t, repos.Listings.Create(listing))
+	e := echo.New()
+	payload := `{
+        "internshiplistingID": ` + strconv.Itoa(int(listing.ID)) + `,
+        "selected_title": "Judul Lama",
+        "nim": "123456",
+        "gpa": 3.5,
+        "education_level": "S1"
+    }`
+
+	codes := []int{}
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/apply-for-internship", strings.NewReader(payload))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		middleware.SetPrincipal(c, userPrincipal(user))
+		assert.NoError(t, h.ApplyForInternship(c))
+		codes = append(codes, rec.Code)
+	}
+	assert.Equal(t, []int{http.StatusOK, http.StatusConflict}, codes)
+
+	listing, err := repos.Listings.FindByID(listing.ID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 4, listing.Quota)
+	}
+}
+
+// seedApplication membuat lowongan dan formulir aplikasi milik user.
+func seedApplication(t *testing.T, repos *repository.Repositories, user *entity.User) *entity.Internship_ApplicationForm {
+	listing := &entity.Internship_Listing{Title: "Software Engineer", Quota: 1, Seats: 1, Status: constants.ListingPublished}
+	assert.NoError(t, repos.Listings.Create(listing))
+	application := &entity.Internship_ApplicationForm{
+		Nim:                 "123456",
+		GPA:                 3.5,
+		EducationLevel:      "S1",
+		Status:              constants.StatusPending,
+		UserID:              user.ID,
+		UserEmail:           user.Email,
+		Username:            user.Username,
+		SelectedTitle:       listing.Title,
+		InternshipListingID: listing.ID,
+	}
+	assert.NoError(t, repos.Applications.Create(application))
+	return application
 }
 
 func TestCancelApplication(t *testing.T) {
+	repos, h, _ := newTestHandlers()
+	user := seedUser(t, repos, "rara", "rara@gmail.com", "rara12", true)
+	application := seedApplication(t, repos, user)
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodDelete, "/cancel-application/1", nil)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
 	c.SetParamNames("id")
-	c.SetParamValues("1")
+	c.SetParamValues(strconv.Itoa(int(application.ID)))
+	middleware.SetPrincipal(c, userPrincipal(user))
 
-	if assert.NoError(t, CancelApplication(c)) {
+	if assert.NoError(t, h.CancelApplication(c)) {
 		assert.Equal(t, http.StatusOK, rec.Code)
 		assert.Contains(t, rec.Body.String(), "Formulir aplikasi berhasil dibatalkan")
 	}
+
+	listing, err := repos.Listings.FindByID(application.InternshipListingID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 2, listing.Quota)
+	}
 }
 func TestCancelApplicationError(t *testing.T) {
+	_, h, _ := newTestHandlers()
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodDelete, "/cancel-application/999", nil)
 	rec := httptest.NewRecorder()
@@ -208,22 +421,211 @@ func TestCancelApplicationError(t *testing.T) {
 	c.SetParamNames("id")
 	c.SetParamValues("999")
 
-	if assert.Error(t, CancelApplication(c)) {
+	if assert.NoError(t, h.CancelApplication(c)) {
 		assert.Equal(t, http.StatusNotFound, rec.Code)
-		assert.Contains(t, rec.Body.String(), "Application not found")
+		assert.Contains(t, rec.Body.String(), "Formulir aplikasi tidak ditemukan")
 	}
 }
 
+func TestAcceptOfferController(t *testing.T) {
+	repos, h, _ := newTestHandlers()
+	user := seedUser(t, repos, "rara", "rara@gmail.com", "rara12", true)
+	other := seedUser(t, repos, "budi", "budi@gmail.com", "budi12", true)
+	application := seedApplication(t, repos, user)
+	application.Status = constants.StatusVerified
+	assert.NoError(t, repos.Applications.Save(application))
+	_, err := h.Applications.Transition(application.ID, constants.StatusAccepted, applicationusecase.Actor{Type: constants.SubjectAdmin, ID: 1}, "Lolos wawancara")
+	assert.NoError(t, err)
+	offers, err := h.Applications.GetUserOffers(user.ID)
+	assert.NoError(t, err)
+	assert.Len(t, offers, 1)
+
+	respond := func(path string, handler echo.HandlerFunc, principal *entity.User) *httptest.ResponseRecorder {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodPost, path, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(strconv.Itoa(int(offers[0].ID)))
+		middleware.SetPrincipal(c, userPrincipal(principal))
+		assert.NoError(t, handler(c))
+		return rec
+	}
+
+	rec := respond("/users/offers/1/accept", h.AcceptOfferController, other)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = respond("/users/offers/1/accept", h.AcceptOfferController, user)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Tawaran magang berhasil diterima")
+
+	rec = respond("/users/offers/1/decline", h.DeclineOfferController, user)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
 func TestGetApplicationStatus(t *testing.T) {
+	repos, h, _ := newTestHandlers()
+	user := seedUser(t, repos, "rara", "rara@gmail.com", "rara12", true)
+	application := seedApplication(t, repos, user)
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/application-status/1", nil)
 	rec := httptest.NewRecorder()
 	c := e.NewContext(req, rec)
 	c.SetParamNames("id")
-	c.SetParamValues("1")
+	c.SetParamValues(strconv.Itoa(int(application.ID)))
+	middleware.SetPrincipal(c, userPrincipal(user))
 
-	if assert.NoError(t, GetApplicationStatus(c)) {
+	if assert.NoError(t, h.GetApplicationStatus(c)) {
 		assert.Equal(t, http.StatusOK, rec.Code)
 		assert.Contains(t, rec.Body.String(), "Status form aplikasi")
 	}
 }
+
+func TestCancelApplicationForbidden(t *testing.T) {
+	repos, h, _ := newTestHandlers()
+	user := seedUser(t, repos, "rara", "rara@gmail.com", "rara12", true)
+	other := seedUser(t, repos, "budi", "budi@gmail.com", "budi12", true)
+	application := seedApplication(t, repos, user)
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/cancel-application/1", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(int(application.ID)))
+	middleware.SetPrincipal(c, userPrincipal(other))
+
+	if assert.NoError(t, h.CancelApplication(c)) {
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Contains(t, rec.Body.String(), constants.ErrForbidden)
+	}
+
+	// Formulir milik pengguna lain tidak ikut dibatalkan
+	stored, err := repos.Applications.FindByID(application.ID)
+	if assert.NoError(t, err) {
+		assert.Equal(t, constants.StatusPending, stored.Status)
+	}
+}
+
+func TestGetApplicationStatusForbidden(t *testing.T) {
+	repos, h, _ := newTestHandlers()
+	user := seedUser(t, repos, "rara", "rara@gmail.com", "rara12", true)
+	other := seedUser(t, repos, "budi", "budi@gmail.com", "budi12", true)
+	application := seedApplication(t, repos, user)
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/application-status/1", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues(strconv.Itoa(int(application.ID)))
+	middleware.SetPrincipal(c, userPrincipal(other))
+
+	if assert.NoError(t, h.GetApplicationStatus(c)) {
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Contains(t, rec.Body.String(), constants.ErrForbidden)
+		assert.NotContains(t, rec.Body.String(), "Status form aplikasi")
+	}
+}
+
+func TestUpdateUserByIDForbidden(t *testing.T) {
+	_, h, _ := newTestHandlers()
+	e := echo.New()
+	payload := `{
+        "Username": "raras",
+        "Email": "raras@gmail.com",
+        "Password": "raras12"
+    }`
+	req := httptest.NewRequest(http.MethodPut, "/users/2", strings.NewReader(payload))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("2")
+	middleware.SetPrincipal(c, &middleware.Principal{ID: 1, Username: "rara", Type: constants.SubjectUser, Role: constants.RoleUser})
+
+	if assert.NoError(t, h.UpdateUserByID(c)) {
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Contains(t, rec.Body.String(), constants.ErrForbidden)
+	}
+}
+
+func TestDeleteUserForbidden(t *testing.T) {
+	_, h, _ := newTestHandlers()
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/users/2", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("2")
+	middleware.SetPrincipal(c, &middleware.Principal{ID: 1, Username: "rara", Type: constants.SubjectUser, Role: constants.RoleUser})
+
+	if assert.NoError(t, h.DeleteUser(c)) {
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Contains(t, rec.Body.String(), constants.ErrForbidden)
+	}
+}
+
+func TestDeleteUserForbiddenWithoutPrincipal(t *testing.T) {
+	_, h, _ := newTestHandlers()
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/users/1", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	if assert.NoError(t, h.DeleteUser(c)) {
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	}
+}
+
+func TestOwnerOrAdmin(t *testing.T) {
+	owner := &middleware.Principal{ID: 1, Type: constants.SubjectUser, Role: constants.RoleUser}
+	admin := &middleware.Principal{ID: 2, Type: constants.SubjectAdmin, Role: constants.RoleAdmin}
+	// subject admin tanpa role admin tidak boleh dianggap pemilik user dengan ID yang sama
+	userWithAdminID := &middleware.Principal{ID: 1, Type: constants.SubjectAdmin, Role: constants.RoleUser}
+
+	assert.True(t, middleware.OwnerOrAdmin(owner, 1))
+	assert.False(t, middleware.OwnerOrAdmin(owner, 2))
+	assert.True(t, middleware.OwnerOrAdmin(admin, 1))
+	assert.False(t, middleware.OwnerOrAdmin(userWithAdminID, 1))
+	assert.False(t, middleware.OwnerOrAdmin(nil, 1))
+}
+
+func TestApplyForInternshipEmailNotVerified(t *testing.T) {
+	_, h, _ := newTestHandlers()
+	e := echo.New()
+	payload := `{
+        "SelectedTitle": "Software Engineer",
+        "Nim": "123456",
+        "GPA": 3.5,
+        "EducationLevel": "S1"
+    }`
+	req := httptest.NewRequest(http.MethodPost, "/apply-for-internship", strings.NewReader(payload))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	middleware.SetPrincipal(c, &middleware.Principal{
+		ID:   1,
+		Type: constants.SubjectUser,
+		Role: constants.RoleUser,
+		User: &entity.User{Username: "rara", Email: "rara@gmail.com"},
+	})
+
+	if assert.NoError(t, h.ApplyForInternship(c)) {
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+		assert.Contains(t, rec.Body.String(), constants.ErrEmailNotVerified)
+	}
+}
+
+func TestResendVerificationEmailRequiresUser(t *testing.T) {
+	_, h, _ := newTestHandlers()
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/users/verify/resend", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	middleware.SetPrincipal(c, &middleware.Principal{ID: 1, Type: constants.SubjectAdmin, Role: constants.RoleUser})
+
+	if assert.NoError(t, h.ResendVerificationEmailController(c)) {
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	}
+}
@@ -0,0 +1,81 @@
+package controllers
+
+import (
+	"miniproject/entity"
+	"miniproject/helpers"
+	"miniproject/middleware"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// sendVerificationEmail membuat token verifikasi untuk email pengguna saat ini lalu mengirimkannya.
+func sendVerificationEmail(user *entity.User) error {
+	token, err := middleware.CreateEmailVerificationToken(user.ID, user.Email)
+	if err != nil {
+		return err
+	}
+	return helpers.SendVerificationEmail(user.Email, user.Username, token)
+}
+
+// VerifyEmailController menandai email pengguna sebagai terverifikasi berdasarkan token dari email.
+func (h *UserHandler) VerifyEmailController(c echo.Context) error {
+	userID, email, err := middleware.ParseEmailVerificationToken(c.QueryParam("token"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "Token verifikasi tidak valid atau sudah kedaluwarsa",
+		})
+	}
+
+	user, err := h.Users.FindByID(userID)
+	if err != nil || user.Email != email {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "Token verifikasi tidak valid atau sudah kedaluwarsa",
+		})
+	}
+
+	if user.EmailVerifiedAt != nil {
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"message": "Email sudah diverifikasi",
+		})
+	}
+
+	now := time.Now()
+	user.EmailVerifiedAt = &now
+	if err := h.Users.Save(user); err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": "Gagal memverifikasi email",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Email berhasil diverifikasi",
+	})
+}
+
+// ResendVerificationEmailController mengirim ulang email verifikasi untuk pengguna yang sedang login.
+func (h *UserHandler) ResendVerificationEmailController(c echo.Context) error {
+	principal := middleware.GetPrincipal(c)
+	if principal == nil || principal.User == nil {
+		return middleware.Forbidden(c)
+	}
+	user := principal.User
+	if user.EmailVerifiedAt != nil {
+		return c.JSON(http.StatusBadRequest, map[string]interface{}{
+			"message": "Email sudah diverifikasi",
+		})
+	}
+
+	if err := sendVerificationEmail(user); err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]interface{}{
+			"message": "Gagal mengirim email verifikasi",
+			"error":   err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"message": "Email verifikasi telah dikirim",
+	})
+}
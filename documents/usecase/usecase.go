@@ -0,0 +1,236 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"miniproject/applications/status"
+	"miniproject/constants"
+	"miniproject/entity"
+	"miniproject/helpers"
+	"miniproject/infra/storage"
+	"miniproject/repository"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultMaxDocumentSizeMB adalah ukuran maksimum dokumen jika MAXDOCUMENTSIZEMB tidak diatur.
+	DefaultMaxDocumentSizeMB = 5
+	// DefaultDownloadURLMinutes adalah masa berlaku URL unduhan jika DOCUMENTURLMINUTES tidak diatur.
+	DefaultDownloadURLMinutes = 15
+	// MaxRecommendationLetters adalah jumlah surat rekomendasi maksimum per formulir.
+	MaxRecommendationLetters = 3
+)
+
+var (
+	ErrInvalidDocumentType    = errors.New("Jenis dokumen harus cv, transcript, atau recommendation_letter")
+	ErrUnsupportedContentType = errors.New("Dokumen harus berupa PDF, PNG, atau JPEG")
+	ErrDocumentTooLarge       = errors.New("Ukuran dokumen melebihi batas yang diizinkan")
+	ErrEmptyDocument          = errors.New("Dokumen tidak boleh kosong")
+	ErrTooManyDocuments       = errors.New("Jumlah surat rekomendasi sudah mencapai batas")
+	ErrApplicationClosed      = errors.New("Dokumen tidak dapat diubah untuk formulir yang sudah dibatalkan atau ditolak")
+)
+
+// extensions memetakan content type yang diizinkan ke ekstensi berkas yang disimpan.
+var extensions = map[string]string{
+	"application/pdf": ".pdf",
+	"image/png":       ".png",
+	"image/jpeg":      ".jpg",
+}
+
+// replaceable adalah jenis dokumen yang hanya boleh satu per formulir; unggahan baru menggantikan
+// dokumen lama.
+var replaceable = map[string]bool{
+	constants.DocumentCV:         true,
+	constants.DocumentTranscript: true,
+}
+
+// DownloadLink adalah dokumen beserta URL unduhan bertanda tangan untuk admin.
+type DownloadLink struct {
+	entity.ApplicationDocument
+	URL       string    `json:"download_url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// DocumentUsecase berisi aturan unggah dan unduh dokumen pendukung formulir pendaftaran.
+type DocumentUsecase interface {
+	Upload(application *entity.Internship_ApplicationForm, docType, fileName string, r io.Reader) (*entity.ApplicationDocument, error)
+	UploadAnswer(application *entity.Internship_ApplicationForm, question *entity.ApplicationQuestion, fileName string, r io.Reader) (*entity.ApplicationDocument, error)
+	GetDocuments(applicationID uint) ([]entity.ApplicationDocument, error)
+	GetDownloadLinks(applicationID uint) ([]DownloadLink, error)
+}
+
+type documentUsecase struct {
+	documents repository.DocumentRepository
+	storage   storage.Storage
+	now       func() time.Time
+}
+
+func NewDocumentUsecase(documents repository.DocumentRepository, store storage.Storage) DocumentUsecase {
+	return &documentUsecase{
+		documents: documents,
+		storage:   store,
+		now:       time.Now,
+	}
+}
+
+// maxDocumentSize membaca ukuran maksimum dokumen (dalam byte) dari MAXDOCUMENTSIZEMB.
+func maxDocumentSize() int64 {
+	value, err := strconv.Atoi(os.Getenv("MAXDOCUMENTSIZEMB"))
+	if err != nil || value <= 0 {
+		value = DefaultMaxDocumentSizeMB
+	}
+	return int64(value) << 20
+}
+
+// downloadURLTTL membaca masa berlaku URL unduhan dari DOCUMENTURLMINUTES.
+func downloadURLTTL() time.Duration {
+	value, err := strconv.Atoi(os.Getenv("DOCUMENTURLMINUTES"))
+	if err != nil || value <= 0 {
+		value = DefaultDownloadURLMinutes
+	}
+	return time.Duration(value) * time.Minute
+}
+
+// Upload memvalidasi jenis, isi, dan ukuran dokumen lalu menyimpannya beserta checksum SHA-256.
+// Content type ditentukan dari isi berkas, bukan dari header yang dikirim klien.
+func (uc *documentUsecase) Upload(application *entity.Internship_ApplicationForm, docType, fileName string, r io.Reader) (*entity.ApplicationDocument, error) {
+	if docType != constants.DocumentCV && docType != constants.DocumentTranscript && docType != constants.DocumentRecommendation {
+		return nil, ErrInvalidDocumentType
+	}
+	if status.IsTerminal(application.Status) {
+		return nil, ErrApplicationClosed
+	}
+
+	existing, err := uc.documents.FindByApplicationID(application.ID)
+	if err != nil {
+		return nil, err
+	}
+	var sameType []entity.ApplicationDocument
+	for _, document := range existing {
+		if document.Type == docType {
+			sameType = append(sameType, document)
+		}
+	}
+	if !replaceable[docType] {
+		if len(sameType) >= MaxRecommendationLetters {
+			return nil, ErrTooManyDocuments
+		}
+		sameType = nil
+	}
+	return uc.store(application, entity.ApplicationDocument{Type: docType, FileName: fileName}, r, sameType)
+}
+
+// UploadAnswer menyimpan berkas jawaban untuk pertanyaan file pada lowongan. Berkas jawaban
+// sebelumnya untuk pertanyaan yang sama digantikan.
+func (uc *documentUsecase) UploadAnswer(application *entity.Internship_ApplicationForm, question *entity.ApplicationQuestion, fileName string, r io.Reader) (*entity.ApplicationDocument, error) {
+	if status.IsTerminal(application.Status) {
+		return nil, ErrApplicationClosed
+	}
+
+	existing, err := uc.documents.FindByApplicationID(application.ID)
+	if err != nil {
+		return nil, err
+	}
+	var previous []entity.ApplicationDocument
+	for _, document := range existing {
+		if document.Type == constants.DocumentAnswer && document.QuestionKey == question.Key {
+			previous = append(previous, document)
+		}
+	}
+	document := entity.ApplicationDocument{Type: constants.DocumentAnswer, QuestionKey: question.Key, FileName: fileName}
+	return uc.store(application, document, r, previous)
+}
+
+// store membaca dan memvalidasi isi berkas, menyimpannya ke storage beserta metadatanya, lalu
+// menghapus dokumen replaced yang digantikannya.
+func (uc *documentUsecase) store(application *entity.Internship_ApplicationForm, document entity.ApplicationDocument, r io.Reader, replaced []entity.ApplicationDocument) (*entity.ApplicationDocument, error) {
+	// Baca satu byte melebihi batas untuk mengetahui apakah berkas terlalu besar
+	limit := maxDocumentSize()
+	content, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(content)) > limit {
+		return nil, ErrDocumentTooLarge
+	}
+	if len(content) == 0 {
+		return nil, ErrEmptyDocument
+	}
+	contentType := http.DetectContentType(content)
+	extension, ok := extensions[contentType]
+	if !ok {
+		return nil, ErrUnsupportedContentType
+	}
+
+	token, err := helpers.GenerateToken()
+	if err != nil {
+		return nil, err
+	}
+	checksum := sha256.Sum256(content)
+	document.InternshipApplicationFormID = application.ID
+	document.UserID = application.UserID
+	document.FileName = filepath.Base(document.FileName)
+	document.ContentType = contentType
+	document.Size = int64(len(content))
+	document.Checksum = hex.EncodeToString(checksum[:])
+	document.StorageKey = fmt.Sprintf("applications/%d/%s/%s%s", application.ID, document.Type, token, extension)
+
+	ctx := context.Background()
+	if err := uc.storage.Put(ctx, document.StorageKey, contentType, bytes.NewReader(content)); err != nil {
+		return nil, err
+	}
+	if err := uc.documents.Create(&document); err != nil {
+		uc.remove(ctx, document.StorageKey)
+		return nil, err
+	}
+
+	for _, old := range replaced {
+		if err := uc.documents.Delete(old.ID); err != nil {
+			log.Println("gagal menghapus dokumen lama:", err)
+			continue
+		}
+		uc.remove(ctx, old.StorageKey)
+	}
+	return &document, nil
+}
+
+// remove menghapus berkas dari storage; kegagalan hanya dicatat karena datanya sudah tidak dirujuk.
+func (uc *documentUsecase) remove(ctx context.Context, key string) {
+	if err := uc.storage.Delete(ctx, key); err != nil {
+		log.Println("gagal menghapus berkas dokumen:", err)
+	}
+}
+
+func (uc *documentUsecase) GetDocuments(applicationID uint) ([]entity.ApplicationDocument, error) {
+	return uc.documents.FindByApplicationID(applicationID)
+}
+
+// GetDownloadLinks membuat URL unduhan bertanda tangan yang berlaku selama DOCUMENTURLMINUTES
+// untuk setiap dokumen formulir.
+func (uc *documentUsecase) GetDownloadLinks(applicationID uint) ([]DownloadLink, error) {
+	documents, err := uc.documents.FindByApplicationID(applicationID)
+	if err != nil {
+		return nil, err
+	}
+
+	expires := uc.now().Add(downloadURLTTL())
+	links := make([]DownloadLink, 0, len(documents))
+	for _, document := range documents {
+		url, err := uc.storage.SignedURL(document.StorageKey, expires)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, DownloadLink{ApplicationDocument: document, URL: url, ExpiresAt: expires})
+	}
+	return links, nil
+}
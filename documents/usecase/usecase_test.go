@@ -0,0 +1,97 @@
+package usecase
+
+import (
+	"bytes"
+	"miniproject/constants"
+	"miniproject/entity"
+	"miniproject/infra/storage"
+	"miniproject/repository"
+	"miniproject/repository/memory"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var pdf = []byte("%PDF-1.4\n1 0 obj\n<<>>\nendobj\n")
+
+func newTestUsecase(t *testing.T) (*repository.Repositories, DocumentUsecase, *entity.Internship_ApplicationForm) {
+	repos := memory.NewRepositories()
+	store, err := storage.NewLocal(t.TempDir(), "http://localhost/files", []byte("secret"))
+	assert.NoError(t, err)
+	application := &entity.Internship_ApplicationForm{UserID: 1, Status: constants.StatusPending}
+	assert.NoError(t, repos.Applications.Create(application))
+	return repos, NewDocumentUsecase(repos.Documents, store), application
+}
+
+func TestUploadValidatesDocument(t *testing.T) {
+	_, uc, application := newTestUsecase(t)
+
+	_, err := uc.Upload(application, "ktp", "ktp.pdf", bytes.NewReader(pdf))
+	assert.ErrorIs(t, err, ErrInvalidDocumentType)
+
+	_, err = uc.Upload(application, constants.DocumentCV, "cv.pdf", bytes.NewReader(nil))
+	assert.ErrorIs(t, err, ErrEmptyDocument)
+
+	// Ekstensi .pdf tidak cukup, isi berkas yang menentukan jenisnya
+	_, err = uc.Upload(application, constants.DocumentCV, "cv.pdf", strings.NewReader("<html><script></script></html>"))
+	assert.ErrorIs(t, err, ErrUnsupportedContentType)
+
+	t.Setenv("MAXDOCUMENTSIZEMB", "1")
+	large := append(append([]byte{}, pdf...), make([]byte, 1<<20)...)
+	_, err = uc.Upload(application, constants.DocumentCV, "cv.pdf", bytes.NewReader(large))
+	assert.ErrorIs(t, err, ErrDocumentTooLarge)
+
+	application.Status = constants.StatusRejected
+	_, err = uc.Upload(application, constants.DocumentCV, "cv.pdf", bytes.NewReader(pdf))
+	assert.ErrorIs(t, err, ErrApplicationClosed)
+}
+
+func TestUploadReplacesCV(t *testing.T) {
+	_, uc, application := newTestUsecase(t)
+
+	first, err := uc.Upload(application, constants.DocumentCV, "../../cv-lama.pdf", bytes.NewReader(pdf))
+	if assert.NoError(t, err) {
+		assert.Equal(t, "cv-lama.pdf", first.FileName)
+		assert.Equal(t, "application/pdf", first.ContentType)
+		assert.Len(t, first.Checksum, 64)
+	}
+	second, err := uc.Upload(application, constants.DocumentCV, "cv-baru.pdf", bytes.NewReader(pdf))
+	assert.NoError(t, err)
+
+	documents, err := uc.GetDocuments(application.ID)
+	if assert.NoError(t, err) && assert.Len(t, documents, 1) {
+		assert.Equal(t, second.ID, documents[0].ID)
+	}
+}
+
+func TestUploadLimitsRecommendationLetters(t *testing.T) {
+	_, uc, application := newTestUsecase(t)
+
+	for i := 0; i < MaxRecommendationLetters; i++ {
+		_, err := uc.Upload(application, constants.DocumentRecommendation, "surat.pdf", bytes.NewReader(pdf))
+		assert.NoError(t, err)
+	}
+	_, err := uc.Upload(application, constants.DocumentRecommendation, "surat.pdf", bytes.NewReader(pdf))
+	assert.ErrorIs(t, err, ErrTooManyDocuments)
+}
+
+func TestGetDownloadLinks(t *testing.T) {
+	_, uc, application := newTestUsecase(t)
+	now := time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC)
+	uc.(*documentUsecase).now = func() time.Time { return now }
+
+	document, err := uc.Upload(application, constants.DocumentTranscript, "transkrip.pdf", bytes.NewReader(pdf))
+	assert.NoError(t, err)
+
+	links, err := uc.GetDownloadLinks(application.ID)
+	if assert.NoError(t, err) && assert.Len(t, links, 1) {
+		assert.Equal(t, now.Add(DefaultDownloadURLMinutes*time.Minute), links[0].ExpiresAt)
+		link, err := url.Parse(links[0].URL)
+		assert.NoError(t, err)
+		assert.Equal(t, "/files/"+document.StorageKey, link.Path)
+		assert.NotEmpty(t, link.Query().Get("signature"))
+	}
+}
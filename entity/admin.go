@@ -1,18 +1,42 @@
 package entity
 
-import "gorm.io/gorm"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Admin struct {
 	gorm.Model
-	Username string `json:"username" form:"username" gorm:"unique;not null"`
-	Email    string `json:"email" form:"email" gorm:"unique;not null"`
-	Password string `json:"password" form:"password" gorm:"not null"`
-	Role     string `gorm:"type:enum('user','admin');default:'admin'"`
+	Username              string `json:"username" form:"username" gorm:"unique;not null"`
+	Email                 string `json:"email" form:"email" gorm:"unique;not null"`
+	Password              string `json:"-" form:"-" gorm:"not null"`
+	Role                  string `gorm:"type:enum('user','admin');default:'admin'"`
+	PasswordResetRequired bool   `json:"password_reset_required" gorm:"default:false"`
+	TOTPSecret            string `json:"-"`
+	TOTPEnabled           bool   `json:"totp_enabled" gorm:"default:false"`
+	TOTPLastUsedStep      int64  `json:"-"`
 }
 
 type AdminResponse struct {
-	ID       uint   `json:"id"`
-	Username string `json:"username"`
-	Email    string `json:"email"`
-	Token    string `json:"token"`
+	ID           uint   `json:"id"`
+	Username     string `json:"username"`
+	Email        string `json:"email"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// AdminBootstrap adalah baris penanda bahwa admin pertama sudah dibuat melalui registrasi terbuka.
+// Primary key tetap membuat dua bootstrap paralel tidak dapat sama-sama berhasil.
+type AdminBootstrap struct {
+	ID        uint `gorm:"primaryKey;autoIncrement:false"`
+	CreatedAt time.Time
+}
+
+// AdminRecoveryCode menyimpan hash kode pemulihan 2FA yang hanya dapat dipakai sekali.
+type AdminRecoveryCode struct {
+	gorm.Model
+	AdminID  uint   `gorm:"index;not null"`
+	CodeHash string `gorm:"size:64;not null"`
+	UsedAt   *time.Time
 }
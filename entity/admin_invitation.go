@@ -0,0 +1,20 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AdminInvitation adalah undangan dari admin yang sudah ada untuk membuat akun admin baru.
+// Token hanya disimpan dalam bentuk hash dan hanya dapat dipakai satu kali.
+type AdminInvitation struct {
+	gorm.Model
+	Email       string     `json:"email" gorm:"size:191;index;not null"`
+	Role        string     `json:"role" gorm:"size:16;not null"`
+	TokenHash   string     `json:"-" gorm:"size:64;uniqueIndex;not null"`
+	ExpiresAt   time.Time  `json:"expires_at" gorm:"not null"`
+	InvitedByID uint       `json:"invited_by_id" gorm:"index;not null"`
+	AcceptedAt  *time.Time `json:"accepted_at"`
+	RevokedAt   *time.Time `json:"revoked_at"`
+}
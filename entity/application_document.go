@@ -0,0 +1,19 @@
+package entity
+
+import "gorm.io/gorm"
+
+// ApplicationDocument adalah berkas pendukung formulir pendaftaran (CV, transkrip, surat
+// rekomendasi, atau jawaban pertanyaan berkas QuestionKey). Isinya disimpan di backend storage
+// dengan key StorageKey.
+type ApplicationDocument struct {
+	gorm.Model
+	InternshipApplicationFormID uint   `json:"application_id" gorm:"not null;index"`
+	UserID                      uint   `json:"user_id" gorm:"not null;index"`
+	Type                        string `json:"type" gorm:"size:32;not null"`
+	QuestionKey                 string `json:"question_key,omitempty" gorm:"size:64"`
+	FileName                    string `json:"file_name" gorm:"not null"`
+	ContentType                 string `json:"content_type" gorm:"size:64;not null"`
+	Size                        int64  `json:"size"`
+	Checksum                    string `json:"checksum" gorm:"size:64;not null"`
+	StorageKey                  string `json:"-" gorm:"not null"`
+}
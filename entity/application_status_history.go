@@ -0,0 +1,19 @@
+package entity
+
+import "gorm.io/gorm"
+
+// ApplicationStatusHistory mencatat setiap perubahan status formulir pendaftaran,
+// termasuk siapa yang mengubahnya dan alasannya.
+type ApplicationStatusHistory struct {
+	gorm.Model
+	InternshipApplicationFormID uint   `json:"application_id" gorm:"index;not null"`
+	FromStatus                  string `json:"from_status" gorm:"size:32"`
+	ToStatus                    string `json:"to_status" gorm:"size:32;not null"`
+	ChangedByType               string `json:"changed_by_type" gorm:"size:16;not null"`
+	ChangedByID                 uint   `json:"changed_by_id"`
+	Reason                      string `json:"reason"`
+}
+
+func (ApplicationStatusHistory) TableName() string {
+	return "application_status_history"
+}
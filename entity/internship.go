@@ -1,34 +1,49 @@
 package entity
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 )
 
 type Internship_Listing struct {
 	gorm.Model
-	Title            string                       `json:"title" form:"title"`
-	Description      string                       `json:"description" form:"description"`
-	Quota            int                          `json:"quota" form:"quota"`
-	Qualifications   string                       `json:"qualifications" form:"qualifications"`
-	StartDate        string                       `json:"start_date" form:"start_date"`
-	EndDate          string                       `json:"end_date" form:"end_date"`
-	ApplicationForms []Internship_ApplicationForm `gorm:"foreignKey:InternshipListingID" json:"applicationforms" form:"applicationforms"`   
+	Title               string                       `json:"title" form:"title"`
+	Description         string                       `json:"description" form:"description"`
+	Quota               int                          `json:"quota" form:"quota"`
+	Seats               int                          `json:"seats" form:"seats"`
+	Qualifications      string                       `json:"qualifications" form:"qualifications"`
+	Status              string                       `json:"status" form:"status" gorm:"size:16;not null;default:draft;index"`
+	StartDate           *time.Time                   `json:"start_date" form:"start_date"`
+	EndDate             *time.Time                   `json:"end_date" form:"end_date"`
+	ApplicationDeadline *time.Time                   `json:"application_deadline" form:"application_deadline"`
+	ScreeningCriteria   *ScreeningCriteria           `json:"screening_criteria" form:"-" gorm:"type:text;serializer:json"`
+	RankingWeights      *RankingWeights              `json:"ranking_weights" form:"-" gorm:"type:text;serializer:json"`
+	Questions           []ApplicationQuestion        `json:"questions" form:"-" gorm:"type:text;serializer:json"`
+	ApplicationForms    []Internship_ApplicationForm `gorm:"foreignKey:InternshipListingID" json:"applicationforms" form:"applicationforms"`
 }
 
 type Internship_ApplicationForm struct {
 	gorm.Model
-	CV                  string               `json:"cv" form:"cv"`
-	Nim                 string               `json:"nim" form:"nim"`
-	GPA                 float64              `json:"gpa" form:"gpa"`
-	EducationLevel      string               `json:"education_level" form:"education_level"`
-	UserID              int                  `json:"UserID " form:"UserID"`
-	Status              string               `json:"status"`
-	UserEmail           string               `json:"user_email" form:"user_email" gorm:"not null"`
-	Username            string               `json:"username" form:"username" gorm:"not null"`
-	SelectedTitle       string               `json:"selected_title" form:"selected_title"`
-	IsCanceled          bool                 `json:"is_canceled" form:"is_canceled"`
-	InternshipListingID uint                 `json:"internshiplistingID" form:"internshiplistingID" gorm:"not null"`
-	Selected_Candidates []Selected_Candidate `gorm:"foreignKey:InternshipApplicationFormID" json:"selected_candidates" form:"selected_candidates"`
+	// Deprecated: CV berupa teks bebas hanya tersisa untuk formulir lama dan tidak lagi diisi;
+	// CV kini diunggah sebagai ApplicationDocument bertipe cv.
+	CV                  string                `json:"cv" form:"-"`
+	Nim                 string                `json:"nim" form:"nim"`
+	GPA                 float64               `json:"gpa" form:"gpa"`
+	EducationLevel      string                `json:"education_level" form:"education_level"`
+	Major               string                `json:"major" form:"-"`
+	UserID              uint                  `json:"user_id" form:"-" gorm:"not null;index"`
+	Status              string                `json:"status"`
+	SelectionRank       int                   `json:"selection_rank" form:"-"`
+	SelectionScore      float64               `json:"selection_score" form:"-"`
+	UserEmail           string                `json:"user_email" form:"user_email" gorm:"not null"`
+	Username            string                `json:"username" form:"username" gorm:"not null"`
+	SelectedTitle       string                `json:"selected_title" form:"selected_title"`
+	IsCanceled          bool                  `json:"is_canceled" form:"is_canceled"`
+	InternshipListingID uint                  `json:"internshiplistingID" form:"internshiplistingID" gorm:"not null"`
+	Answers             []ApplicationAnswer   `json:"answers" form:"-" gorm:"type:text;serializer:json"`
+	Selected_Candidates []Selected_Candidate  `gorm:"foreignKey:InternshipApplicationFormID" json:"selected_candidates" form:"selected_candidates"`
+	Documents           []ApplicationDocument `gorm:"foreignKey:InternshipApplicationFormID" json:"-" form:"-"`
 }
 
 type Selected_Candidate struct {
@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// LoginThrottle mencatat percobaan login gagal per username ("user:<username>",
+// "admin:<username>") maupun per alamat IP ("ip:<alamat>").
+type LoginThrottle struct {
+	gorm.Model
+	Identifier   string     `json:"identifier" gorm:"size:191;uniqueIndex;not null"`
+	Failures     int        `json:"failures"`
+	LastFailedAt time.Time  `json:"last_failed_at"`
+	LockedUntil  *time.Time `json:"locked_until"`
+}
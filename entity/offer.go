@@ -0,0 +1,19 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Offer adalah tawaran tempat magang untuk formulir yang diterima. Kandidat harus menerima atau
+// menolak tawaran sebelum ExpiresAt; tawaran yang tidak dijawab kedaluwarsa dan kursinya dilepas.
+type Offer struct {
+	gorm.Model
+	InternshipApplicationFormID uint       `json:"application_id" gorm:"not null;uniqueIndex"`
+	InternshipListingID         uint       `json:"listing_id" gorm:"not null;index"`
+	UserID                      uint       `json:"user_id" gorm:"not null;index"`
+	Status                      string     `json:"status" gorm:"size:16;not null;index"`
+	ExpiresAt                   time.Time  `json:"expires_at" gorm:"not null"`
+	RespondedAt                 *time.Time `json:"responded_at"`
+}
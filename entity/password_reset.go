@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PasswordResetToken menyimpan hash token reset password yang hanya boleh dipakai sekali.
+type PasswordResetToken struct {
+	gorm.Model
+	SubjectType string    `gorm:"size:16;index;not null"`
+	SubjectID   uint      `gorm:"index;not null"`
+	TokenHash   string    `gorm:"size:64;uniqueIndex;not null"`
+	ExpiresAt   time.Time `gorm:"not null"`
+	UsedAt      *time.Time
+}
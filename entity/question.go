@@ -0,0 +1,27 @@
+package entity
+
+// ApplicationQuestion adalah pertanyaan tambahan pada formulir pendaftaran satu lowongan.
+// Options hanya dipakai pertanyaan choice, Min dan Max hanya dipakai pertanyaan number, dan
+// MaxLength hanya dipakai pertanyaan text. Aturan validasinya ada di package applications/questions.
+type ApplicationQuestion struct {
+	Key       string   `json:"key"`
+	Label     string   `json:"label"`
+	Type      string   `json:"type"`
+	Required  bool     `json:"required"`
+	Options   []string `json:"options,omitempty"`
+	Min       *float64 `json:"min,omitempty"`
+	Max       *float64 `json:"max,omitempty"`
+	MaxLength int      `json:"max_length,omitempty"`
+}
+
+// ApplicationAnswer adalah jawaban pendaftar untuk satu pertanyaan. Label dan Type disalin dari
+// pertanyaan saat formulir disimpan sehingga jawaban tetap terbaca walaupun pertanyaan diubah.
+// Jawaban pertanyaan file merujuk ke ApplicationDocument lewat DocumentID.
+type ApplicationAnswer struct {
+	Key        string   `json:"key"`
+	Label      string   `json:"label"`
+	Type       string   `json:"type"`
+	Text       string   `json:"text,omitempty"`
+	Number     *float64 `json:"number,omitempty"`
+	DocumentID uint     `json:"document_id,omitempty"`
+}
@@ -0,0 +1,28 @@
+package entity
+
+// ScreeningCriteria adalah kriteria penyaringan kandidat untuk satu lowongan magang.
+// Field yang kosong (nol atau list kosong) tidak diperiksa.
+type ScreeningCriteria struct {
+	MinGPA          float64         `json:"min_gpa"`
+	MaxGPA          float64         `json:"max_gpa"`
+	EducationLevels []string        `json:"education_levels"`
+	Majors          []string        `json:"majors"`
+	Rules           []ScreeningRule `json:"rules"`
+}
+
+// ScreeningRule adalah aturan boolean kustom: fakta Fact milik pendaftar harus bernilai Expected.
+// Daftar fakta yang dikenal ada di package applications/screening.
+type ScreeningRule struct {
+	Name     string `json:"name"`
+	Fact     string `json:"fact"`
+	Expected bool   `json:"expected"`
+}
+
+// RankingWeights adalah bobot kriteria untuk mengurutkan kandidat pada selection run.
+// Skor kandidat adalah jumlah bobot dikali nilai kriteria yang sudah dinormalisasi ke 0..1.
+type RankingWeights struct {
+	GPA             float64            `json:"gpa"`
+	HasCV           float64            `json:"has_cv"`
+	EarlyApply      float64            `json:"early_apply"`
+	EducationLevels map[string]float64 `json:"education_levels"`
+}
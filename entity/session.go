@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// RefreshToken disimpan dalam bentuk hash. Token yang dirotasi tetap berada dalam
+// FamilyID yang sama sehingga pemakaian ulang token lama dapat mencabut seluruh family.
+type RefreshToken struct {
+	gorm.Model
+	SubjectType string    `gorm:"size:16;index;not null"`
+	SubjectID   uint      `gorm:"index;not null"`
+	TokenHash   string    `gorm:"size:64;uniqueIndex;not null"`
+	FamilyID    string    `gorm:"size:64;index;not null"`
+	ExpiresAt   time.Time `gorm:"not null"`
+	RevokedAt   *time.Time
+}
+
+// RevokedToken adalah denylist access token. JTI kosong berarti seluruh access token
+// milik subject yang diterbitkan sebelum CreatedAt dianggap tidak berlaku.
+type RevokedToken struct {
+	gorm.Model
+	JTI         string    `gorm:"size:64;index"`
+	SubjectType string    `gorm:"size:16;index;not null"`
+	SubjectID   uint      `gorm:"index;not null"`
+	ExpiresAt   time.Time `gorm:"index;not null"`
+}
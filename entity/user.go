@@ -1,21 +1,25 @@
 package entity
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 )
 
 type User struct {
 	gorm.Model
-	Username          string                       `json:"username" form:"username" gorm:"unique;not null"`
-	Password          string                       `json:"password" form:"password" gorm:"not null"`
-	Email             string                       `json:"email" form:"email" gorm:"unique;not null"`
-	Gender            string                       `json:"gender" form:"gender"`
-	PhoneNumber       string                       `json:"phone_number" form:"phone_number"`
-	UniversityName    string                       `json:"university_name" form:"university_name"`
-	UniversityAddress string                       `json:"university_address" form:"university_address"`
-	Major             string                       `json:"major" form:"major"`
-	Role              string                       `gorm:"type:enum('user','admin');default:'user'"`
-	Form              []Internship_ApplicationForm //`gorm:"foreignKey:UserID"`
+	Username              string                       `json:"username" form:"username" gorm:"unique;not null"`
+	Password              string                       `json:"-" form:"-" gorm:"not null"`
+	Email                 string                       `json:"email" form:"email" gorm:"unique;not null"`
+	Gender                string                       `json:"gender" form:"gender"`
+	PhoneNumber           string                       `json:"phone_number" form:"phone_number"`
+	UniversityName        string                       `json:"university_name" form:"university_name"`
+	UniversityAddress     string                       `json:"university_address" form:"university_address"`
+	Major                 string                       `json:"major" form:"major"`
+	Role                  string                       `gorm:"type:enum('user','admin');default:'user'"`
+	EmailVerifiedAt       *time.Time                   `json:"email_verified_at"`
+	PasswordResetRequired bool                         `json:"password_reset_required" gorm:"default:false"`
+	Form                  []Internship_ApplicationForm `gorm:"foreignKey:UserID"`
 }
 
 type ErrorResponse struct {
@@ -24,8 +28,9 @@ type ErrorResponse struct {
 }
 
 type UserResponse struct {
-	ID       uint   `json:"id"`
-	Username string `json:"username"`
-	Email    string `json:"email"`
-	Token    string `json:"token"`
+	ID           uint   `json:"id"`
+	Username     string `json:"username"`
+	Email        string `json:"email"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
 }
@@ -0,0 +1,33 @@
+package helpers
+
+import (
+	"html"
+	"net/url"
+)
+
+// SendAdminInvitationEmail mengirim tautan undangan untuk membuat akun admin.
+func SendAdminInvitationEmail(email, invitedBy, token string) error {
+	link := AppURL("/admin/invitations/accept?token=" + url.QueryEscape(token))
+
+	message := `
+		<!DOCTYPE html>
+		<html>
+		<head>
+			<meta charset="UTF-8">
+			<title>Undangan Admin</title>
+		</head>
+		<body style="font-family: Arial, sans-serif; background-color: #f3f3f3;">
+			<div style="max-width: 600px; margin: 0 auto; padding: 20px; background-color: #ffffff;">
+				<h1 style="color: #0073e6;">Undangan Admin</h1>
+				<p>Halo,</p>
+				<p>` + html.EscapeString(invitedBy) + ` mengundang Anda menjadi admin sistem pendaftaran magang PT Krisnadwipayana. Gunakan tautan berikut untuk menyelesaikan pendaftaran:</p>
+				<p><a href="` + html.EscapeString(link) + `">Terima Undangan</a></p>
+				<p>Atau gunakan token berikut: <strong>` + html.EscapeString(token) + `</strong></p>
+				<p>Undangan ini hanya dapat digunakan satu kali dan memiliki batas waktu. Jika Anda tidak mengenal pengirim undangan, abaikan email ini.</p>
+			</div>
+		</body>
+		</html>
+	`
+
+	return sendEmail(email, "Undangan Admin Magang", message)
+}
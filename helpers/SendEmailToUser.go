@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"gopkg.in/gomail.v2"
@@ -18,18 +19,6 @@ func SendEmailToUser(userEmail, username, _ string) error {
 	// Menambahkan 3 bulan ke "Mulai Tanggal"
 	endDate := currentDate.AddDate(0, 3, 0).Format("02/01/2006")
 
-	// Mengambil konfigurasi server SMTP dari variabel lingkungan
-	smtpServer := os.Getenv("SMTPSERVER")
-	smtpPortStr := os.Getenv("SMTPPORT")
-	smtpUsername := os.Getenv("SMTPUSERNAME")
-	smtpPassword := os.Getenv("SMTPPASSWORD")
-
-	// Konversi smtpPortStr menjadi int
-	smtpPort, err := strconv.Atoi(smtpPortStr)
-	if err != nil {
-		return err
-	}
-
 	// Pesan email dalam format HTML
 	message := `
 		<!DOCTYPE html>
@@ -90,7 +79,7 @@ func SendEmailToUser(userEmail, username, _ string) error {
 
 				<p><strong>Langkah Selanjutnya:</strong></p>
 				<ol>
-					<li>Konfirmasi Kehadiran: Tolong konfirmasi penerimaan ini dengan membalas email ini atau menghubungi Mutiakhoirunniza di <a href="mailto:mutiakhoirunniza@ac.id">mutiakhoirunniza@ac.id</a> atau 08316281026.</li>
+					<li>Konfirmasi Kehadiran: Terima atau tolak tawaran magang Anda melalui menu <a href="` + AppURL("/users/offers") + `">Tawaran Magang</a> sebelum batas waktu yang tertera pada tawaran. Tawaran yang tidak dijawab akan kedaluwarsa dan tempat Anda diberikan kepada pendaftar lain.</li>
 					<li>Dokumen-dokumen: Kami akan mengirimkan Anda berkas persyaratan dan formulir yang perlu Anda isi sebagai persyaratan magang. Mohon lengkapi dan kembalikan dokumen-dokumen ini sesegera mungkin.</li>
 				</ol>
 
@@ -111,15 +100,32 @@ func SendEmailToUser(userEmail, username, _ string) error {
 		</html>
 	`
 
+	return sendEmail(userEmail, "Selamat Bergabung Sebagai Magang", message)
+}
+
+// sendEmail mengirim email HTML menggunakan konfigurasi SMTP dari variabel lingkungan.
+func sendEmail(to, subject, body string) error {
+	// Mengambil konfigurasi server SMTP dari variabel lingkungan
+	smtpServer := os.Getenv("SMTPSERVER")
+	smtpPortStr := os.Getenv("SMTPPORT")
+	smtpUsername := os.Getenv("SMTPUSERNAME")
+	smtpPassword := os.Getenv("SMTPPASSWORD")
+
+	// Konversi smtpPortStr menjadi int
+	smtpPort, err := strconv.Atoi(smtpPortStr)
+	if err != nil {
+		return err
+	}
+
 	// Konfigurasi pengiriman email menggunakan gomail
 	d := gomail.NewDialer(smtpServer, smtpPort, smtpUsername, smtpPassword)
 
 	// Membuat pesan email
 	m := gomail.NewMessage()
 	m.SetHeader("From", smtpUsername)
-	m.SetHeader("To", userEmail)
-	m.SetHeader("Subject", "Selamat Bergabung Sebagai Magang")
-	m.SetBody("text/html", message)
+	m.SetHeader("To", to)
+	m.SetHeader("Subject", subject)
+	m.SetBody("text/html", body)
 
 	// Mengirim email
 	if err := d.DialAndSend(m); err != nil {
@@ -128,3 +134,12 @@ func SendEmailToUser(userEmail, username, _ string) error {
 
 	return nil
 }
+
+// AppURL membangun URL absolut ke aplikasi dari APPBASEURL (default http://localhost:SERVERPORT).
+func AppURL(path string) string {
+	baseURL := os.Getenv("APPBASEURL")
+	if baseURL == "" {
+		baseURL = "http://localhost:" + os.Getenv("SERVERPORT")
+	}
+	return strings.TrimRight(baseURL, "/") + path
+}
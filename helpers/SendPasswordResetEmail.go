@@ -0,0 +1,39 @@
+package helpers
+
+import (
+	"html"
+	"net/url"
+	"strings"
+)
+
+// SendPasswordResetEmail mengirim tautan reset password. resetPage adalah URL halaman yang
+// menampilkan form reset (halaman front-end atau form bawaan), token ditambahkan sebagai query.
+func SendPasswordResetEmail(email, username, resetPage, token string) error {
+	separator := "?"
+	if strings.Contains(resetPage, "?") {
+		separator = "&"
+	}
+	link := resetPage + separator + "token=" + url.QueryEscape(token)
+
+	message := `
+		<!DOCTYPE html>
+		<html>
+		<head>
+			<meta charset="UTF-8">
+			<title>Reset Password</title>
+		</head>
+		<body style="font-family: Arial, sans-serif; background-color: #f3f3f3;">
+			<div style="max-width: 600px; margin: 0 auto; padding: 20px; background-color: #ffffff;">
+				<h1 style="color: #0073e6;">Reset Password</h1>
+				<p>Halo, ` + html.EscapeString(username) + `</p>
+				<p>Kami menerima permintaan untuk mereset password akun Anda. Gunakan tautan berikut untuk membuat password baru:</p>
+				<p><a href="` + html.EscapeString(link) + `">Reset Password</a></p>
+				<p>Atau gunakan token berikut: <strong>` + html.EscapeString(token) + `</strong></p>
+				<p>Tautan ini hanya dapat digunakan satu kali dan akan kedaluwarsa dalam waktu singkat. Jika Anda tidak meminta reset password, abaikan email ini.</p>
+			</div>
+		</body>
+		</html>
+	`
+
+	return sendEmail(email, "Reset Password Akun Magang", message)
+}
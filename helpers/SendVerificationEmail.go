@@ -0,0 +1,32 @@
+package helpers
+
+import (
+	"html"
+	"net/url"
+)
+
+// SendVerificationEmail mengirim tautan verifikasi email kepada pengguna yang baru mendaftar.
+func SendVerificationEmail(userEmail, username, token string) error {
+	link := AppURL("/users/verify?token=" + url.QueryEscape(token))
+
+	message := `
+		<!DOCTYPE html>
+		<html>
+		<head>
+			<meta charset="UTF-8">
+			<title>Verifikasi Email Anda</title>
+		</head>
+		<body style="font-family: Arial, sans-serif; background-color: #f3f3f3;">
+			<div style="max-width: 600px; margin: 0 auto; padding: 20px; background-color: #ffffff;">
+				<h1 style="color: #0073e6;">Verifikasi Email Anda</h1>
+				<p>Halo, ` + html.EscapeString(username) + `</p>
+				<p>Terima kasih telah mendaftar di sistem pendaftaran magang PT. Krisnadwipayana. Silakan verifikasi alamat email Anda dengan menekan tautan berikut:</p>
+				<p><a href="` + html.EscapeString(link) + `">Verifikasi Email</a></p>
+				<p>Tautan ini hanya berlaku selama 24 jam. Jika Anda tidak merasa mendaftar, abaikan email ini.</p>
+			</div>
+		</body>
+		</html>
+	`
+
+	return sendEmail(userEmail, "Verifikasi Email Pendaftaran Magang", message)
+}
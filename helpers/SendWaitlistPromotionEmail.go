@@ -0,0 +1,35 @@
+package helpers
+
+import (
+	"html"
+	"strconv"
+	"time"
+)
+
+// SendWaitlistPromotionEmail memberi tahu pendaftar bahwa ia dipromosikan dari daftar tunggu dan
+// harus menerima offer-nya sebelum deadline.
+func SendWaitlistPromotionEmail(email, username, title string, offerID uint, deadline time.Time) error {
+	link := AppURL("/users/offers/" + strconv.FormatUint(uint64(offerID), 10) + "/accept")
+
+	message := `
+		<!DOCTYPE html>
+		<html>
+		<head>
+			<meta charset="UTF-8">
+			<title>Promosi Daftar Tunggu</title>
+		</head>
+		<body style="font-family: Arial, sans-serif; background-color: #f3f3f3;">
+			<div style="max-width: 600px; margin: 0 auto; padding: 20px; background-color: #ffffff;">
+				<h1 style="color: #0073e6;">Selamat, Anda Diterima!</h1>
+				<p>Halo, ` + html.EscapeString(username) + `</p>
+				<p>Sebuah tempat pada program magang <strong>` + html.EscapeString(title) + `</strong> telah tersedia dan Anda diterima dari daftar tunggu.</p>
+				<p>Terima atau tolak tawaran ini sebelum <strong>` + deadline.Format("02 Jan 2006 15:04 MST") + `</strong> melalui menu Tawaran Magang atau endpoint berikut:</p>
+				<p><a href="` + html.EscapeString(link) + `">Terima Tawaran Magang</a></p>
+				<p>Jika tidak dijawab sampai batas waktu tersebut, tempat Anda akan diberikan kepada pendaftar berikutnya.</p>
+			</div>
+		</body>
+		</html>
+	`
+
+	return sendEmail(email, "Anda Diterima dari Daftar Tunggu Magang", message)
+}
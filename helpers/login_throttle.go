@@ -0,0 +1,135 @@
+package helpers
+
+import (
+	"math"
+	"miniproject/repository"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// throttlePolicy mengatur kapan jeda progresif dimulai dan kapan identitas dikunci sementara.
+type throttlePolicy struct {
+	delayAfter int
+	lockAfter  int
+}
+
+const (
+	// Kegagalan yang lebih lama dari failureWindow tidak lagi dihitung
+	failureWindow = time.Minute * 15
+	maxLoginDelay = time.Minute * 1
+)
+
+func envInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+func loginPolicy(identifier string) throttlePolicy {
+	if strings.HasPrefix(identifier, "ip:") {
+		return throttlePolicy{delayAfter: 10, lockAfter: envInt("LOGINMAXATTEMPTSIP", 50)}
+	}
+	return throttlePolicy{delayAfter: 3, lockAfter: envInt("LOGINMAXATTEMPTS", 10)}
+}
+
+func loginLockDuration() time.Duration {
+	return time.Duration(envInt("LOGINLOCKMINUTES", 15)) * time.Minute
+}
+
+// LoginThrottleKey membentuk identifier throttle untuk username pada tipe akun tertentu.
+func LoginThrottleKey(subjectType, username string) string {
+	return subjectType + ":" + strings.ToLower(strings.TrimSpace(username))
+}
+
+// LoginThrottleKeys mengembalikan identifier username dan IP untuk satu percobaan login.
+// Username dilacak walaupun tidak terdaftar agar respons tidak membocorkan keberadaan akun.
+func LoginThrottleKeys(subjectType, username, ip string) []string {
+	return []string{LoginThrottleKey(subjectType, username), "ip:" + ip}
+}
+
+// PasswordResetThrottleKeys mengembalikan identifier email dan IP untuk satu permintaan reset
+// password. Keduanya terpisah dari identifier login sehingga permintaan reset tidak mengunci login,
+// tetapi identifier IP tetap memakai kebijakan IP.
+func PasswordResetThrottleKeys(subjectType, email, ip string) []string {
+	return []string{"reset:" + LoginThrottleKey(subjectType, email), "ip:reset:" + ip}
+}
+
+// LoginThrottler menerapkan kebijakan throttle login di atas penyimpanan repository.
+type LoginThrottler struct {
+	Store repository.LoginThrottleRepository
+}
+
+func NewLoginThrottler(store repository.LoginThrottleRepository) *LoginThrottler {
+	return &LoginThrottler{Store: store}
+}
+
+// Check mengembalikan lama waktu tunggu jika salah satu identifier sedang dikunci
+// atau masih dalam jeda progresif. Nilai nol berarti login boleh dicoba. Error dari repository
+// dikembalikan agar pemanggil menolak login daripada menonaktifkan perlindungan brute force.
+func (t *LoginThrottler) Check(identifiers []string) (time.Duration, error) {
+	throttles, err := t.Store.FindByIdentifiers(identifiers)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	var retryAfter time.Duration
+	for _, throttle := range throttles {
+		wait := time.Duration(0)
+		if throttle.LockedUntil != nil && throttle.LockedUntil.After(now) {
+			wait = throttle.LockedUntil.Sub(now)
+		} else if now.Sub(throttle.LastFailedAt) < failureWindow {
+			wait = throttle.LastFailedAt.Add(loginDelay(throttle.Failures, loginPolicy(throttle.Identifier))).Sub(now)
+		}
+		if wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+	return retryAfter, nil
+}
+
+// loginDelay menghitung jeda eksponensial (1s, 2s, 4s, ...) setelah batas delayAfter terlampaui.
+func loginDelay(failures int, policy throttlePolicy) time.Duration {
+	if failures < policy.delayAfter {
+		return 0
+	}
+	delay := time.Second * time.Duration(math.Pow(2, float64(failures-policy.delayAfter)))
+	if delay > maxLoginDelay || delay <= 0 {
+		return maxLoginDelay
+	}
+	return delay
+}
+
+// RecordFailure menambah hitungan gagal untuk setiap identifier dan mengunci sementara
+// identifier yang melewati batas.
+func (t *LoginThrottler) RecordFailure(identifiers []string) error {
+	now := time.Now()
+	for _, identifier := range identifiers {
+		throttle, err := t.Store.RecordFailure(identifier, now, failureWindow)
+		if err != nil {
+			return err
+		}
+		if throttle.Failures >= loginPolicy(identifier).lockAfter {
+			if err := t.Store.Lock(identifier, now.Add(loginLockDuration())); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Reset menghapus catatan gagal setelah login berhasil.
+func (t *LoginThrottler) Reset(identifier string) error {
+	_, err := t.Store.Delete(identifier)
+	return err
+}
+
+// Unlock membuka kunci identifier secara manual (dipakai oleh admin).
+// Mengembalikan false jika identifier tidak sedang tercatat.
+func (t *LoginThrottler) Unlock(identifier string) (bool, error) {
+	return t.Store.Delete(identifier)
+}
@@ -0,0 +1,85 @@
+package helpers
+
+import (
+	"errors"
+	"miniproject/entity"
+	"miniproject/repository"
+	"miniproject/repository/memory"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoginDelay(t *testing.T) {
+	policy := throttlePolicy{delayAfter: 3, lockAfter: 10}
+	assert.Equal(t, time.Duration(0), loginDelay(2, policy))
+	assert.Equal(t, time.Second, loginDelay(3, policy))
+	assert.Equal(t, 4*time.Second, loginDelay(5, policy))
+	assert.Equal(t, maxLoginDelay, loginDelay(30, policy))
+}
+
+func TestLoginThrottlerProgressiveDelay(t *testing.T) {
+	throttler := NewLoginThrottler(memory.NewRepositories().LoginThrottles)
+	keys := LoginThrottleKeys("user", "Rara", "10.0.0.1")
+
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, throttler.RecordFailure(keys))
+	}
+	retryAfter, err := throttler.Check(keys)
+	assert.NoError(t, err)
+	assert.Zero(t, retryAfter)
+
+	// Mulai kegagalan ketiga, username harus menunggu sebelum mencoba lagi
+	assert.NoError(t, throttler.RecordFailure(keys))
+	retryAfter, err = throttler.Check(keys)
+	assert.NoError(t, err)
+	assert.True(t, retryAfter > 0 && retryAfter <= time.Second, retryAfter)
+
+	// Username dinormalisasi sehingga huruf besar tidak melewati jeda
+	retryAfter, _ = throttler.Check([]string{LoginThrottleKey("user", " rara ")})
+	assert.True(t, retryAfter > 0)
+}
+
+func TestLoginThrottlerLockAndReset(t *testing.T) {
+	t.Setenv("LOGINMAXATTEMPTS", "5")
+	t.Setenv("LOGINLOCKMINUTES", "15")
+	throttler := NewLoginThrottler(memory.NewRepositories().LoginThrottles)
+	keys := LoginThrottleKeys("admin", "caca", "10.0.0.1")
+
+	for i := 0; i < 5; i++ {
+		assert.NoError(t, throttler.RecordFailure(keys))
+	}
+	retryAfter, err := throttler.Check(keys[:1])
+	assert.NoError(t, err)
+	assert.True(t, retryAfter > 14*time.Minute, retryAfter)
+
+	// Identitas lain dari IP yang sama belum mencapai batas IP
+	retryAfter, _ = throttler.Check(LoginThrottleKeys("admin", "lain", "10.0.0.1"))
+	assert.Zero(t, retryAfter)
+
+	assert.NoError(t, throttler.Reset(keys[0]))
+	retryAfter, err = throttler.Check(keys[:1])
+	assert.NoError(t, err)
+	assert.Zero(t, retryAfter)
+
+	unlocked, err := throttler.Unlock(keys[0])
+	assert.NoError(t, err)
+	assert.False(t, unlocked)
+}
+
+// failingThrottles mensimulasikan repository throttle yang tidak dapat diakses.
+type failingThrottles struct {
+	repository.LoginThrottleRepository
+}
+
+func (failingThrottles) FindByIdentifiers(identifiers []string) ([]entity.LoginThrottle, error) {
+	return nil, errors.New("database tidak tersedia")
+}
+
+func TestLoginThrottlerFailsClosed(t *testing.T) {
+	throttler := NewLoginThrottler(failingThrottles{})
+
+	_, err := throttler.Check(LoginThrottleKeys("user", "rara", "10.0.0.1"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,72 @@
+package helpers
+
+import (
+	"crypto/subtle"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// bcryptCost membaca cost bcrypt dari variabel lingkungan BCRYPTCOST,
+// menggunakan bcrypt.DefaultCost jika tidak diset atau tidak valid.
+func bcryptCost() int {
+	cost, err := strconv.Atoi(os.Getenv("BCRYPTCOST"))
+	if err != nil || cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		return bcrypt.DefaultCost
+	}
+	return cost
+}
+
+// IsPasswordHashed mengecek apakah password yang tersimpan sudah berupa hash bcrypt.
+func IsPasswordHashed(stored string) bool {
+	return strings.HasPrefix(stored, "$2a$") ||
+		strings.HasPrefix(stored, "$2b$") ||
+		strings.HasPrefix(stored, "$2y$")
+}
+
+// HashPassword menghasilkan hash bcrypt dari password dengan cost yang dikonfigurasi.
+func HashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcryptCost())
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// VerifyPassword membandingkan password dengan nilai yang tersimpan secara constant-time.
+// needsRehash bernilai true jika password cocok tetapi nilai tersimpan masih plaintext
+// (data lama) atau di-hash dengan cost yang berbeda dari konfigurasi saat ini.
+func VerifyPassword(stored, password string) (match bool, needsRehash bool) {
+	if stored == "" {
+		return false, false
+	}
+
+	if !IsPasswordHashed(stored) {
+		match = subtle.ConstantTimeCompare([]byte(stored), []byte(password)) == 1
+		return match, match
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(stored), []byte(password)); err != nil {
+		return false, false
+	}
+
+	cost, err := bcrypt.Cost([]byte(stored))
+	return true, err != nil || cost != bcryptCost()
+}
+
+var (
+	dummyHashOnce sync.Once
+	dummyHash     []byte
+)
+
+// DummyVerifyPassword menjalankan perbandingan bcrypt terhadap hash palsu ketika akun tidak
+// ditemukan, sehingga waktu respons login tidak membocorkan apakah username terdaftar.
+func DummyVerifyPassword(password string) {
+	dummyHashOnce.Do(func() {
+		dummyHash, _ = bcrypt.GenerateFromPassword([]byte("dummy-password"), bcryptCost())
+	})
+	bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+}
@@ -0,0 +1,116 @@
+package helpers
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Parameter TOTP mengikuti default RFC 6238 yang didukung aplikasi authenticator umum.
+const (
+	totpPeriod = 30
+	totpDigits = 6
+	// totpSkew mengizinkan selisih satu langkah waktu sebelum/sesudah untuk toleransi jam
+	totpSkew = 1
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTOTPSecret menghasilkan secret acak 160-bit dalam format base32.
+func GenerateTOTPSecret() (string, error) {
+	buf := make([]byte, 20)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(buf), nil
+}
+
+// TOTPProvisioningURI membangun URI otpauth:// yang dapat diubah menjadi QR code.
+func TOTPProvisioningURI(secret, issuer, accountName string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(totpDigits))
+	query.Set("period", fmt.Sprint(totpPeriod))
+
+	label := url.PathEscape(issuer + ":" + accountName)
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+// TOTPCode menghitung kode TOTP untuk langkah waktu tertentu (RFC 6238 / RFC 4226).
+func TOTPCode(secret string, step int64) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(strings.TrimSpace(secret)))
+	if err != nil {
+		return "", err
+	}
+
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, value%1000000), nil
+}
+
+// TOTPStep mengembalikan langkah waktu TOTP untuk waktu t.
+func TOTPStep(t time.Time) int64 {
+	return t.Unix() / totpPeriod
+}
+
+// ValidateTOTP memvalidasi kode terhadap langkah waktu saat ini ± totpSkew. Langkah yang
+// sudah dipakai (<= lastStep) ditolak agar kode yang sama tidak bisa digunakan ulang.
+// Mengembalikan langkah waktu yang cocok untuk disimpan sebagai lastStep berikutnya.
+func ValidateTOTP(secret, code string, now time.Time, lastStep int64) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != totpDigits {
+		return 0, false
+	}
+
+	current := TOTPStep(now)
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if step <= lastStep {
+			continue
+		}
+		expected, err := TOTPCode(secret, step)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// GenerateRecoveryCodes menghasilkan kode pemulihan sekali pakai dengan format xxxxx-xxxxx.
+func GenerateRecoveryCodes(n int) ([]string, error) {
+	codes := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		buf := make([]byte, 7)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, err
+		}
+		encoded := strings.ToLower(totpEncoding.EncodeToString(buf))[:10]
+		codes = append(codes, encoded[:5]+"-"+encoded[5:])
+	}
+	return codes, nil
+}
+
+// Spasi dan tanda hubung diabaikan, jadi kode boleh diketik dengan atau tanpa pemisah.
+var recoveryCodeSeparators = strings.NewReplacer(" ", "", "-", "")
+
+// NormalizeRecoveryCode menyeragamkan input kode pemulihan sebelum di-hash.
+func NormalizeRecoveryCode(code string) string {
+	return strings.ToLower(recoveryCodeSeparators.Replace(strings.TrimSpace(code)))
+}
@@ -0,0 +1,55 @@
+package helpers
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Vektor uji RFC 6238 (SHA1) dipotong menjadi 6 digit.
+func TestTOTPCodeRFC6238(t *testing.T) {
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+	vectors := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+
+	for unix, expected := range vectors {
+		code, err := TOTPCode(secret, TOTPStep(time.Unix(unix, 0)))
+		if assert.NoError(t, err) {
+			assert.Equal(t, expected, code, "time %d", unix)
+		}
+	}
+}
+
+func TestValidateTOTPRejectsReplay(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	assert.NoError(t, err)
+
+	now := time.Now()
+	code, err := TOTPCode(secret, TOTPStep(now))
+	assert.NoError(t, err)
+
+	step, ok := ValidateTOTP(secret, code, now, 0)
+	assert.True(t, ok)
+	assert.Equal(t, TOTPStep(now), step)
+
+	_, ok = ValidateTOTP(secret, code, now, step)
+	assert.False(t, ok)
+
+	_, ok = ValidateTOTP(secret, "000000x", now, 0)
+	assert.False(t, ok)
+}
+
+func TestNormalizeRecoveryCode(t *testing.T) {
+	inputs := []string{"abcde-12345", "ABCDE-12345", "abcde12345", " abcde 12345 ", "ab-cde-12345"}
+	for _, input := range inputs {
+		assert.Equal(t, "abcde12345", NormalizeRecoveryCode(input), "input %q", input)
+	}
+}
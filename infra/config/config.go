@@ -6,11 +6,6 @@ import (
 
 	"github.com/joho/godotenv"
 	"github.com/sirupsen/logrus"
-	"gorm.io/gorm"
-)
-
-var (
-	DB *gorm.DB
 )
 
 type AppConfig struct {
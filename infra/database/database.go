@@ -19,6 +19,5 @@ func InitDBMysql(cfg *config.AppConfig) *gorm.DB {
 	if err != nil {
 		panic(err)
 	}
-	config.DB = db
 	return db
 }
\ No newline at end of file
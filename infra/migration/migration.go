@@ -1,16 +1,229 @@
 package migration
 
 import (
-    "miniproject/entity"
-    "gorm.io/gorm"
+	"fmt"
+	"miniproject/constants"
+	"miniproject/entity"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 )
 
-func InitMigrationMysql(db *gorm.DB) {
-    db.AutoMigrate(
-        &entity.User{},
-        &entity.Admin{},
-        &entity.Internship_Listing{}, 
-        &entity.Internship_ApplicationForm{}, 
-        &entity.Selected_Candidate{})
+// InitMigrationMysql menyiapkan skema basis data. Error dikembalikan agar aplikasi tidak berjalan
+// dengan skema yang hanya termigrasi sebagian, karena AutoMigrate berhenti pada tabel pertama yang gagal.
+func InitMigrationMysql(db *gorm.DB) error {
+	LinkApplicationsToUsers(db)
+	if err := CheckOrphanApplications(db); err != nil {
+		return err
+	}
+	legacyListings := RenameLegacyListingColumns(db)
+
+	err := db.AutoMigrate(
+		&entity.User{},
+		&entity.Admin{},
+		&entity.AdminBootstrap{},
+		&entity.Internship_Listing{},
+		&entity.Internship_ApplicationForm{},
+		&entity.Selected_Candidate{},
+		&entity.RefreshToken{},
+		&entity.RevokedToken{},
+		&entity.PasswordResetToken{},
+		&entity.LoginThrottle{},
+		&entity.AdminRecoveryCode{},
+		&entity.AdminInvitation{},
+		&entity.ApplicationStatusHistory{},
+		&entity.Offer{},
+		&entity.ApplicationDocument{})
+	if err != nil {
+		return fmt.Errorf("auto migrate: %w", err)
+	}
+
+	MigrateLegacyListings(db, legacyListings)
+	FlagPlaintextPasswords(db)
+	NormalizeApplicationStatuses(db)
+	return nil
+}
+
+// LinkApplicationsToUsers menghubungkan ulang formulir lama ke akun pemiliknya berdasarkan
+// user_email sebelum foreign key ke tabel users dibuat. Dahulu UserID diambil dari request
+// body sehingga nilainya bisa tidak merujuk ke akun mana pun.
+func LinkApplicationsToUsers(db *gorm.DB) {
+	if !db.Migrator().HasTable(&entity.Internship_ApplicationForm{}) {
+		return
+	}
+	result := db.Exec(`UPDATE internship_application_forms AS f
+		JOIN users AS u ON u.email = f.user_email
+		SET f.user_id = u.id
+		WHERE NOT EXISTS (SELECT 1 FROM users AS owner WHERE owner.id = f.user_id)`)
+	if result.Error != nil {
+		logrus.Error("Migration : failed to link applications to users, ", result.Error.Error())
+		return
+	}
+	if result.RowsAffected > 0 {
+		logrus.Infof("Migration : %d application(s) linked to their user account", result.RowsAffected)
+	}
+}
+
+// CheckOrphanApplications menolak melanjutkan migrasi jika setelah LinkApplicationsToUsers masih
+// ada formulir yang user_id-nya tidak merujuk ke akun mana pun. Foreign key ke tabel users tidak
+// dapat dibuat selama data tersebut ada, sehingga formulir perlu diperbaiki atau dihapus manual.
+func CheckOrphanApplications(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&entity.Internship_ApplicationForm{}) || !db.Migrator().HasTable(&entity.User{}) {
+		return nil
+	}
+	var ids []uint
+	err := db.Table("internship_application_forms AS f").
+		Where("NOT EXISTS (SELECT 1 FROM users AS owner WHERE owner.id = f.user_id)").
+		Pluck("f.id", &ids).Error
+	if err != nil {
+		return fmt.Errorf("check orphan applications: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	logrus.Errorf("Migration : application(s) %v have no matching user account by user_id or user_email", ids)
+	return fmt.Errorf("%d application(s) without a user account, relink or remove them before migrating", len(ids))
+}
+
+// FlagPlaintextPasswords menandai akun yang password-nya belum berupa hash bcrypt
+// (data sebelum hashing diterapkan) agar diwajibkan melakukan reset password.
+// Akun yang berhasil login akan di-hash ulang dan tandanya dihapus secara otomatis.
+func FlagPlaintextPasswords(db *gorm.DB) {
+	for _, model := range []interface{}{&entity.User{}, &entity.Admin{}} {
+		result := db.Model(model).
+			Where("password NOT LIKE ? AND password_reset_required = ?", "$2_$%", false).
+			Update("password_reset_required", true)
+		if result.Error != nil {
+			logrus.Error("Migration : failed to flag plaintext passwords, ", result.Error.Error())
+			continue
+		}
+		if result.RowsAffected > 0 {
+			logrus.Infof("Migration : %d account(s) flagged for password reset", result.RowsAffected)
+		}
+	}
+}
+
+// NormalizeApplicationStatuses mengubah status formulir lama (kosong atau teks bebas seperti
+// "Dibatalkan") menjadi status yang dikenal state machine di package applications/status.
+func NormalizeApplicationStatuses(db *gorm.DB) {
+	updates := []struct {
+		query  *gorm.DB
+		status string
+	}{
+		{db.Where("is_canceled = ? AND status <> ?", true, constants.StatusCanceled), constants.StatusCanceled},
+		{db.Where("is_canceled = ? AND (status = ? OR status IS NULL)", false, ""), constants.StatusPending},
+	}
+	for _, update := range updates {
+		result := db.Model(&entity.Internship_ApplicationForm{}).Where(update.query).Update("status", update.status)
+		if result.Error != nil {
+			logrus.Error("Migration : failed to normalize application statuses, ", result.Error.Error())
+			continue
+		}
+		if result.RowsAffected > 0 {
+			logrus.Infof("Migration : %d application(s) moved to status %s", result.RowsAffected, update.status)
+		}
+	}
 }
 
+// legacyDateLayouts adalah format tanggal teks bebas yang dahulu dipakai pada start_date dan end_date.
+var legacyDateLayouts = []string{time.RFC3339, "2006-01-02", "02/01/2006", "02-01-2006", "2006/01/02"}
+
+// RenameLegacyListingColumns menyiapkan tabel lowongan lama sebelum AutoMigrate. Kolom tanggal
+// berupa teks diganti nama menjadi legacy_start_date dan legacy_end_date agar AutoMigrate dapat
+// membuat kolom bertipe datetime. Mengembalikan true jika tabel sudah ada sebelum kolom status
+// ditambahkan, sehingga lowongan lamanya (yang selama ini sudah terlihat) perlu dipublikasikan.
+func RenameLegacyListingColumns(db *gorm.DB) bool {
+	migrator := db.Migrator()
+	listing := &entity.Internship_Listing{}
+	if !migrator.HasTable(listing) {
+		return false
+	}
+
+	columnTypes, err := migrator.ColumnTypes(listing)
+	if err != nil {
+		logrus.Error("Migration : failed to read listing columns, ", err.Error())
+		return false
+	}
+	for _, column := range columnTypes {
+		if column.Name() != "start_date" && column.Name() != "end_date" {
+			continue
+		}
+		typeName := strings.ToLower(column.DatabaseTypeName())
+		if !strings.Contains(typeName, "char") && !strings.Contains(typeName, "text") {
+			continue
+		}
+		if err := migrator.RenameColumn(listing, column.Name(), "legacy_"+column.Name()); err != nil {
+			logrus.Error("Migration : failed to rename legacy listing column, ", err.Error())
+		}
+	}
+	return !migrator.HasColumn(listing, "status")
+}
+
+// MigrateLegacyListings mengisi start_date dan end_date dari kolom teks lama lalu menghapus kolom
+// lama jika semua nilainya berhasil dibaca, dan mempublikasikan lowongan lama jika publish true.
+func MigrateLegacyListings(db *gorm.DB, publish bool) {
+	if publish {
+		result := db.Model(&entity.Internship_Listing{}).
+			Where("status = ?", constants.ListingDraft).
+			Update("status", constants.ListingPublished)
+		if result.Error != nil {
+			logrus.Error("Migration : failed to publish existing listings, ", result.Error.Error())
+		} else if result.RowsAffected > 0 {
+			logrus.Infof("Migration : %d existing listing(s) published", result.RowsAffected)
+		}
+	}
+
+	for _, column := range []string{"start_date", "end_date"} {
+		legacyColumn := "legacy_" + column
+		if !db.Migrator().HasColumn(&entity.Internship_Listing{}, legacyColumn) {
+			continue
+		}
+
+		var rows []struct {
+			ID    uint
+			Value string
+		}
+		err := db.Table("internship_listings").
+			Select("id, " + legacyColumn + " AS value").
+			Where(legacyColumn + " IS NOT NULL AND " + legacyColumn + " <> ''").
+			Scan(&rows).Error
+		if err != nil {
+			logrus.Error("Migration : failed to read legacy listing dates, ", err.Error())
+			continue
+		}
+
+		failed := 0
+		for _, row := range rows {
+			date, ok := parseLegacyDate(row.Value)
+			if !ok {
+				failed++
+				logrus.Warnf("Migration : listing %d has unreadable %s %q", row.ID, column, row.Value)
+				continue
+			}
+			if err := db.Table("internship_listings").Where("id = ?", row.ID).Update(column, date).Error; err != nil {
+				failed++
+				logrus.Error("Migration : failed to migrate legacy listing date, ", err.Error())
+			}
+		}
+
+		// Kolom lama dipertahankan jika ada nilai yang tidak terbaca agar dapat diperbaiki manual
+		if failed > 0 {
+			continue
+		}
+		if err := db.Migrator().DropColumn(&entity.Internship_Listing{}, legacyColumn); err != nil {
+			logrus.Error("Migration : failed to drop legacy listing column, ", err.Error())
+		}
+	}
+}
+
+func parseLegacyDate(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	for _, layout := range legacyDateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	gcs "cloud.google.com/go/storage"
+)
+
+// GCS menyimpan berkas di bucket Google Cloud Storage dan memakai signed URL V4 milik GCS.
+type GCS struct {
+	client *gcs.Client
+	bucket string
+}
+
+// NewGCS membuat backend GCS untuk bucket dengan kredensial default Google Cloud.
+func NewGCS(ctx context.Context, bucket string) (*GCS, error) {
+	if bucket == "" {
+		return nil, errors.New("STORAGEBUCKET is required for the gcs storage backend")
+	}
+	client, err := gcs.NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &GCS{client: client, bucket: bucket}, nil
+}
+
+func (g *GCS) Put(ctx context.Context, key, contentType string, r io.Reader) error {
+	writer := g.client.Bucket(g.bucket).Object(key).NewWriter(ctx)
+	writer.ContentType = contentType
+	if _, err := io.Copy(writer, r); err != nil {
+		writer.Close()
+		return err
+	}
+	return writer.Close()
+}
+
+func (g *GCS) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	reader, err := g.client.Bucket(g.bucket).Object(key).NewReader(ctx)
+	if errors.Is(err, gcs.ErrObjectNotExist) {
+		return nil, ErrNotFound
+	}
+	return reader, err
+}
+
+func (g *GCS) Delete(ctx context.Context, key string) error {
+	err := g.client.Bucket(g.bucket).Object(key).Delete(ctx)
+	if errors.Is(err, gcs.ErrObjectNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (g *GCS) SignedURL(key string, expires time.Time) (string, error) {
+	return g.client.Bucket(g.bucket).SignedURL(key, &gcs.SignedURLOptions{
+		Method:  "GET",
+		Expires: expires,
+		Scheme:  gcs.SigningSchemeV4,
+	})
+}
@@ -0,0 +1,144 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Local menyimpan berkas di direktori lokal. URL unduhannya ditandatangani dengan HMAC dan
+// dilayani oleh ServeHTTP sehingga berkas tidak pernah dapat diakses tanpa URL yang masih berlaku.
+type Local struct {
+	dir     string
+	baseURL string
+	key     []byte
+	now     func() time.Time
+}
+
+// NewLocal membuat backend lokal di dir. baseURL adalah URL publik tempat ServeHTTP dipasang,
+// misalnya "http://localhost:8000/files".
+func NewLocal(dir, baseURL string, key []byte) (*Local, error) {
+	if len(key) == 0 {
+		return nil, ErrMissingSigningKey
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+	return &Local{dir: dir, baseURL: strings.TrimRight(baseURL, "/"), key: key, now: time.Now}, nil
+}
+
+// path memetakan key ke path di dalam dir dan menolak key yang keluar dari dir.
+func (l *Local) path(key string) (string, error) {
+	cleaned := filepath.Clean("/" + key)
+	if cleaned == "/" {
+		return "", errors.New("invalid storage key")
+	}
+	return filepath.Join(l.dir, filepath.FromSlash(cleaned)), nil
+}
+
+func (l *Local) Put(_ context.Context, key, _ string, r io.Reader) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+
+	// Tulis ke berkas sementara lalu rename agar pembaca tidak pernah melihat berkas setengah jadi
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (l *Local) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return file, err
+}
+
+func (l *Local) Delete(_ context.Context, key string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (l *Local) SignedURL(key string, expires time.Time) (string, error) {
+	if _, err := l.path(key); err != nil {
+		return "", err
+	}
+	exp := strconv.FormatInt(expires.Unix(), 10)
+	query := url.Values{"expires": {exp}, "signature": {l.sign(key, exp)}}
+	return l.baseURL + "/" + (&url.URL{Path: key}).EscapedPath() + "?" + query.Encode(), nil
+}
+
+func (l *Local) sign(key, expires string) string {
+	mac := hmac.New(sha256.New, l.key)
+	mac.Write([]byte(key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ServeHTTP melayani unduhan dari URL yang dibuat SignedURL. Path request adalah key berkas,
+// sehingga handler harus dipasang dengan http.StripPrefix sesuai baseURL.
+func (l *Local) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.URL.Path, "/")
+	exp := r.URL.Query().Get("expires")
+	signature := r.URL.Query().Get("signature")
+
+	expires, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil || l.now().Unix() > expires ||
+		!hmac.Equal([]byte(signature), []byte(l.sign(key, exp))) {
+		http.Error(w, "URL unduhan tidak valid atau sudah kedaluwarsa", http.StatusForbidden)
+		return
+	}
+
+	path, err := l.path(key)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Disposition", "attachment; filename=\""+filepath.Base(path)+"\"")
+	http.ServeContent(w, r, filepath.Base(path), info.ModTime(), file)
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalPutOpenDelete(t *testing.T) {
+	local, err := NewLocal(t.TempDir(), "http://localhost/files", []byte("secret"))
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	assert.NoError(t, local.Put(ctx, "applications/1/cv/a.pdf", "application/pdf", strings.NewReader("%PDF-1.4")))
+	reader, err := local.Open(ctx, "applications/1/cv/a.pdf")
+	if assert.NoError(t, err) {
+		content, _ := io.ReadAll(reader)
+		reader.Close()
+		assert.Equal(t, "%PDF-1.4", string(content))
+	}
+
+	assert.NoError(t, local.Delete(ctx, "applications/1/cv/a.pdf"))
+	_, err = local.Open(ctx, "applications/1/cv/a.pdf")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// Key dengan ".." tetap berada di dalam direktori penyimpanan
+	assert.NoError(t, local.Put(ctx, "../../escape.pdf", "application/pdf", strings.NewReader("x")))
+	_, err = local.Open(ctx, "escape.pdf")
+	assert.NoError(t, err)
+}
+
+func TestLocalSignedURL(t *testing.T) {
+	local, err := NewLocal(t.TempDir(), "http://localhost/files", []byte("secret"))
+	assert.NoError(t, err)
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	local.now = func() time.Time { return now }
+	assert.NoError(t, local.Put(context.Background(), "applications/1/cv/a.pdf", "application/pdf", strings.NewReader("%PDF-1.4")))
+
+	signed, err := local.SignedURL("applications/1/cv/a.pdf", now.Add(time.Minute))
+	assert.NoError(t, err)
+	parsed, err := url.Parse(signed)
+	assert.NoError(t, err)
+	handler := http.StripPrefix("/files", local)
+
+	download := func(target string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		return rec
+	}
+
+	rec := download(parsed.RequestURI())
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "%PDF-1.4", rec.Body.String())
+
+	// Signature tidak berlaku untuk berkas lain
+	rec = download(strings.Replace(parsed.RequestURI(), "a.pdf", "b.pdf", 1))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	local.now = func() time.Time { return now.Add(2 * time.Minute) }
+	rec = download(parsed.RequestURI())
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestNewFromEnvRequiresSigningKey(t *testing.T) {
+	t.Setenv("STORAGEBACKEND", "local")
+	t.Setenv("STORAGEDIR", t.TempDir())
+	t.Setenv("STORAGESIGNINGKEY", "")
+	t.Setenv("SecretKey", "")
+
+	_, err := NewFromEnv(context.Background(), "http://localhost/files")
+	assert.ErrorIs(t, err, ErrMissingSigningKey)
+
+	t.Setenv("SecretKey", "secret")
+	store, err := NewFromEnv(context.Background(), "http://localhost/files")
+	assert.NoError(t, err)
+	assert.IsType(t, &Local{}, store)
+}
@@ -0,0 +1,49 @@
+// Package storage menyimpan berkas unggahan (CV, transkrip, surat rekomendasi) di backend yang
+// dapat diganti: filesystem lokal untuk pengembangan dan Google Cloud Storage untuk produksi.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"time"
+)
+
+// ErrNotFound dikembalikan jika objek dengan key tersebut tidak ada.
+var ErrNotFound = errors.New("storage object not found")
+
+// ErrMissingSigningKey dikembalikan jika backend lokal dibuat tanpa kunci penandatangan URL.
+var ErrMissingSigningKey = errors.New("storage signing key is empty, set STORAGESIGNINGKEY or SecretKey")
+
+// Storage adalah backend penyimpanan berkas. Key adalah path relatif seperti
+// "applications/12/cv/abc.pdf" dan tidak boleh berasal langsung dari input pengguna.
+type Storage interface {
+	Put(ctx context.Context, key, contentType string, r io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+	// SignedURL membuat URL unduhan yang hanya berlaku sampai expires.
+	SignedURL(key string, expires time.Time) (string, error)
+}
+
+// NewFromEnv memilih backend dari STORAGEBACKEND ("local" atau "gcs", default "local").
+// Backend lokal menyimpan berkas di STORAGEDIR (default "uploads") dan menandatangani URL
+// unduhan dengan STORAGESIGNINGKEY (default SecretKey); backend GCS memakai bucket STORAGEBUCKET
+// dengan kredensial default Google Cloud. Backend lokal menolak dibuat jika kedua kunci kosong.
+func NewFromEnv(ctx context.Context, baseURL string) (Storage, error) {
+	switch os.Getenv("STORAGEBACKEND") {
+	case "gcs":
+		return NewGCS(ctx, os.Getenv("STORAGEBUCKET"))
+	case "", "local":
+		dir := os.Getenv("STORAGEDIR")
+		if dir == "" {
+			dir = "uploads"
+		}
+		key := os.Getenv("STORAGESIGNINGKEY")
+		if key == "" {
+			key = os.Getenv("SecretKey")
+		}
+		return NewLocal(dir, baseURL, []byte(key))
+	}
+	return nil, errors.New("unknown STORAGEBACKEND " + os.Getenv("STORAGEBACKEND"))
+}
@@ -0,0 +1,55 @@
+// Package status mendefinisikan siklus hidup lowongan magang. Lowongan dibuat sebagai draft,
+// baru terlihat dan dapat dilamar setelah published, berhenti menerima pendaftaran saat closed,
+// dan disimpan sebagai arsip saat archived.
+package status
+
+import (
+	"errors"
+	"miniproject/constants"
+	"miniproject/entity"
+	"time"
+)
+
+var ErrInvalidTransition = errors.New("Perubahan status lowongan magang tidak diizinkan")
+
+// transitions memetakan setiap status ke status tujuan yang diizinkan.
+// Status tanpa tujuan adalah status final.
+var transitions = map[string][]string{
+	constants.ListingDraft:     {constants.ListingPublished, constants.ListingArchived},
+	constants.ListingPublished: {constants.ListingClosed},
+	constants.ListingClosed:    {constants.ListingPublished, constants.ListingArchived},
+	constants.ListingArchived:  {},
+}
+
+// IsValid memeriksa apakah status dikenal oleh siklus hidup lowongan.
+func IsValid(status string) bool {
+	_, ok := transitions[status]
+	return ok
+}
+
+// CanTransition memeriksa apakah lowongan boleh berpindah dari status from ke status to.
+func CanTransition(from, to string) bool {
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Check mengembalikan ErrInvalidTransition jika perpindahan status tidak diizinkan.
+func Check(from, to string) error {
+	if !CanTransition(from, to) {
+		return ErrInvalidTransition
+	}
+	return nil
+}
+
+// AcceptsApplications memeriksa apakah lowongan sedang published dan batas pendaftarannya
+// (jika ada) belum lewat pada waktu now.
+func AcceptsApplications(listing *entity.Internship_Listing, now time.Time) bool {
+	if listing.Status != constants.ListingPublished {
+		return false
+	}
+	return listing.ApplicationDeadline == nil || !now.After(*listing.ApplicationDeadline)
+}
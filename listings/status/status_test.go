@@ -0,0 +1,34 @@
+package status
+
+import (
+	"miniproject/constants"
+	"miniproject/entity"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanTransition(t *testing.T) {
+	assert.True(t, CanTransition(constants.ListingDraft, constants.ListingPublished))
+	assert.True(t, CanTransition(constants.ListingPublished, constants.ListingClosed))
+	assert.True(t, CanTransition(constants.ListingClosed, constants.ListingPublished))
+	assert.True(t, CanTransition(constants.ListingClosed, constants.ListingArchived))
+
+	assert.False(t, CanTransition(constants.ListingPublished, constants.ListingDraft))
+	assert.False(t, CanTransition(constants.ListingPublished, constants.ListingArchived))
+	assert.False(t, CanTransition(constants.ListingArchived, constants.ListingPublished))
+	assert.False(t, CanTransition("", constants.ListingPublished))
+}
+
+func TestAcceptsApplications(t *testing.T) {
+	now := time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)
+	deadline := now.Add(time.Hour)
+	passed := now.Add(-time.Hour)
+
+	assert.True(t, AcceptsApplications(&entity.Internship_Listing{Status: constants.ListingPublished}, now))
+	assert.True(t, AcceptsApplications(&entity.Internship_Listing{Status: constants.ListingPublished, ApplicationDeadline: &deadline}, now))
+	assert.False(t, AcceptsApplications(&entity.Internship_Listing{Status: constants.ListingPublished, ApplicationDeadline: &passed}, now))
+	assert.False(t, AcceptsApplications(&entity.Internship_Listing{Status: constants.ListingDraft}, now))
+	assert.False(t, AcceptsApplications(&entity.Internship_Listing{Status: constants.ListingClosed}, now))
+}
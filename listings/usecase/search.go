@@ -0,0 +1,157 @@
+package usecase
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"miniproject/constants"
+	"miniproject/entity"
+	"miniproject/listings/status"
+	"miniproject/repository"
+	"time"
+)
+
+const (
+	// DefaultPageSize adalah jumlah lowongan per halaman jika Limit tidak diisi.
+	DefaultPageSize = 20
+	// MaxPageSize adalah jumlah lowongan maksimum per halaman.
+	MaxPageSize = 100
+)
+
+// ListingSearch berisi parameter pencarian lowongan. Cursor adalah NextCursor dari halaman
+// sebelumnya dan hanya berlaku untuk Sort yang sama.
+type ListingSearch struct {
+	Keyword   string
+	Statuses  []string
+	OpenOnly  bool
+	StartFrom *time.Time
+	EndUntil  *time.Time
+	HasQuota  bool
+	Sort      string
+	Cursor    string
+	Limit     int
+}
+
+// ListingPage adalah satu halaman hasil pencarian. Total adalah jumlah seluruh lowongan yang
+// cocok dengan filter, dan NextCursor kosong jika tidak ada halaman berikutnya.
+type ListingPage struct {
+	Listings   []entity.Internship_Listing `json:"listings"`
+	Total      int64                       `json:"total"`
+	NextCursor string                      `json:"next_cursor,omitempty"`
+}
+
+// pageCursor adalah isi cursor yang dikirim ke klien dalam bentuk base64.
+type pageCursor struct {
+	Sort string `json:"sort"`
+	repository.ListingCursor
+}
+
+// GetListing mengembalikan satu lowongan berdasarkan ID.
+func (uc *listingUsecase) GetListing(id uint) (*entity.Internship_Listing, error) {
+	listing, err := uc.listings.FindByID(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrListingNotFound
+		}
+		return nil, err
+	}
+	return listing, nil
+}
+
+// SearchListings mencari lowongan sesuai filter dan mengembalikan satu halaman hasil.
+func (uc *listingUsecase) SearchListings(search ListingSearch) (*ListingPage, error) {
+	invalidData := make(map[string]string)
+	for _, listingStatus := range search.Statuses {
+		if !status.IsValid(listingStatus) {
+			invalidData["status"] = "Status harus draft, published, closed, atau archived"
+		}
+	}
+	if search.Sort == "" {
+		search.Sort = constants.ListingSortNewest
+	}
+	switch search.Sort {
+	case constants.ListingSortNewest, constants.ListingSortOldest, constants.ListingSortDeadline,
+		constants.ListingSortStartDate, constants.ListingSortTitle:
+	default:
+		invalidData["sort"] = "Urutan harus newest, oldest, deadline, start_date, atau title"
+	}
+	if search.Limit == 0 {
+		search.Limit = DefaultPageSize
+	}
+	if search.Limit < 0 || search.Limit > MaxPageSize {
+		invalidData["limit"] = fmt.Sprintf("Limit harus antara 1 dan %d", MaxPageSize)
+	}
+	if search.StartFrom != nil && search.EndUntil != nil && !search.EndUntil.After(*search.StartFrom) {
+		invalidData["end_until"] = "Tanggal selesai harus setelah tanggal mulai"
+	}
+	after, err := decodeCursor(search.Cursor, search.Sort)
+	if err != nil {
+		invalidData["cursor"] = "Cursor tidak valid untuk pencarian ini"
+	}
+	if len(invalidData) > 0 {
+		return nil, &ValidationError{Fields: invalidData}
+	}
+
+	query := repository.ListingQuery{
+		ListingFilter: repository.ListingFilter{
+			Keyword:   search.Keyword,
+			Statuses:  search.Statuses,
+			StartFrom: search.StartFrom,
+			EndUntil:  search.EndUntil,
+			HasQuota:  search.HasQuota,
+		},
+		Sort:  search.Sort,
+		After: after,
+		// Satu baris tambahan untuk mengetahui apakah masih ada halaman berikutnya
+		Limit: search.Limit + 1,
+	}
+	if search.OpenOnly {
+		now := uc.now()
+		query.OpenAt = &now
+	}
+	listings, total, err := uc.listings.Search(query)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &ListingPage{Listings: listings, Total: total}
+	if len(listings) > search.Limit {
+		page.Listings = listings[:search.Limit]
+		page.NextCursor = encodeCursor(search.Sort, page.Listings[search.Limit-1])
+	}
+	return page, nil
+}
+
+func encodeCursor(sort string, last entity.Internship_Listing) string {
+	cursor := pageCursor{Sort: sort, ListingCursor: repository.ListingCursor{ID: last.ID}}
+	switch sort {
+	case constants.ListingSortTitle:
+		cursor.Title = last.Title
+	case constants.ListingSortDeadline:
+		cursor.Date = last.ApplicationDeadline
+	case constants.ListingSortStartDate:
+		cursor.Date = last.StartDate
+	}
+	encoded, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+// decodeCursor mengembalikan nil tanpa error jika cursor kosong (halaman pertama).
+func decodeCursor(value, sort string) (*repository.ListingCursor, error) {
+	if value == "" {
+		return nil, nil
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	var cursor pageCursor
+	if err := json.Unmarshal(decoded, &cursor); err != nil {
+		return nil, err
+	}
+	if cursor.Sort != sort || cursor.ID == 0 {
+		return nil, errors.New("cursor does not match the search")
+	}
+	return &cursor.ListingCursor, nil
+}
@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"miniproject/constants"
+	"miniproject/entity"
+	"miniproject/repository/memory"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func titles(listings []entity.Internship_Listing) []string {
+	result := make([]string, 0, len(listings))
+	for _, listing := range listings {
+		result = append(result, listing.Title)
+	}
+	return result
+}
+
+func TestSearchListingsFilters(t *testing.T) {
+	repos := memory.NewRepositories()
+	uc := NewListingUsecase(repos.Listings).(*listingUsecase)
+	now := time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)
+	uc.now = func() time.Time { return now }
+	june := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	august := time.Date(2024, 8, 31, 0, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Hour)
+
+	for _, listing := range []*entity.Internship_Listing{
+		{Title: "Backend Developer", Description: "Membangun API dengan Go", Quota: 2, Status: constants.ListingPublished, StartDate: &june, EndDate: &august},
+		{Title: "Data Analyst", Qualifications: "Menguasai SQL dan Go", Quota: 0, Status: constants.ListingPublished},
+		{Title: "UI Designer", Quota: 1, Status: constants.ListingPublished, ApplicationDeadline: &past},
+		{Title: "Go Mentor", Quota: 1, Status: constants.ListingDraft},
+	} {
+		assert.NoError(t, repos.Listings.Create(listing))
+	}
+
+	page, err := uc.SearchListings(ListingSearch{Keyword: "go", Statuses: []string{constants.ListingPublished}})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"Data Analyst", "Backend Developer"}, titles(page.Listings))
+		assert.EqualValues(t, 2, page.Total)
+		assert.Empty(t, page.NextCursor)
+	}
+
+	page, err = uc.SearchListings(ListingSearch{Statuses: []string{constants.ListingPublished}, OpenOnly: true, HasQuota: true})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"Backend Developer"}, titles(page.Listings))
+	}
+
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	page, err = uc.SearchListings(ListingSearch{StartFrom: &from, EndUntil: &august})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"Backend Developer"}, titles(page.Listings))
+	}
+}
+
+func TestSearchListingsPaginates(t *testing.T) {
+	repos := memory.NewRepositories()
+	uc := NewListingUsecase(repos.Listings)
+	first := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	second := first.Add(24 * time.Hour)
+	for _, listing := range []*entity.Internship_Listing{
+		{Title: "A", Quota: 1, ApplicationDeadline: &second},
+		{Title: "B", Quota: 1},
+		{Title: "C", Quota: 1, ApplicationDeadline: &first},
+		{Title: "D", Quota: 1, ApplicationDeadline: &second},
+		{Title: "E", Quota: 1},
+	} {
+		assert.NoError(t, repos.Listings.Create(listing))
+	}
+
+	// Tanggal kosong di akhir, nilai yang sama diurutkan berdasarkan ID
+	var seen []string
+	search := ListingSearch{Sort: constants.ListingSortDeadline, Limit: 2}
+	for {
+		page, err := uc.SearchListings(search)
+		if !assert.NoError(t, err) {
+			return
+		}
+		assert.EqualValues(t, 5, page.Total)
+		seen = append(seen, titles(page.Listings)...)
+		if page.NextCursor == "" {
+			break
+		}
+		search.Cursor = page.NextCursor
+	}
+	assert.Equal(t, []string{"C", "A", "D", "B", "E"}, seen)
+
+	page, err := uc.SearchListings(ListingSearch{Limit: 2})
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"E", "D"}, titles(page.Listings))
+		// Cursor hanya berlaku untuk urutan yang sama
+		_, err = uc.SearchListings(ListingSearch{Sort: constants.ListingSortTitle, Cursor: page.NextCursor})
+		var validationErr *ValidationError
+		if assert.ErrorAs(t, err, &validationErr) {
+			assert.Contains(t, validationErr.Fields, "cursor")
+		}
+	}
+}
+
+func TestSearchListingsValidates(t *testing.T) {
+	uc := NewListingUsecase(memory.NewRepositories().Listings)
+
+	_, err := uc.SearchListings(ListingSearch{Statuses: []string{"deleted"}, Sort: "random", Limit: MaxPageSize + 1, Cursor: "!"})
+	var validationErr *ValidationError
+	if assert.ErrorAs(t, err, &validationErr) {
+		assert.Contains(t, validationErr.Fields, "status")
+		assert.Contains(t, validationErr.Fields, "sort")
+		assert.Contains(t, validationErr.Fields, "limit")
+	}
+
+	_, err = uc.GetListing(999)
+	assert.ErrorIs(t, err, ErrListingNotFound)
+}
@@ -0,0 +1,193 @@
+package usecase
+
+import (
+	"errors"
+	"miniproject/applications/questions"
+	"miniproject/applications/ranking"
+	"miniproject/applications/screening"
+	"miniproject/constants"
+	"miniproject/entity"
+	"miniproject/listings/status"
+	"miniproject/repository"
+	"time"
+)
+
+var (
+	ErrInvalidQuota      = errors.New("Kuota harus lebih dari 0")
+	ErrInvalidSeats      = errors.New("Jumlah kursi tidak boleh negatif")
+	ErrListingNotFound   = errors.New("Lowongan magang tidak ditemukan")
+	ErrInvalidTransition = status.ErrInvalidTransition
+	ErrStatusChanged     = errors.New("Status lowongan magang sudah diubah oleh proses lain, silakan muat ulang")
+)
+
+// ValidationError berisi daftar field lowongan yang tidak valid beserta alasannya.
+type ValidationError struct {
+	Fields map[string]string
+}
+
+func (e *ValidationError) Error() string {
+	return "Data lowongan magang tidak valid"
+}
+
+// validateListing memeriksa kursi, jadwal, kriteria penyaringan, bobot peringkat, dan pertanyaan
+// tambahan lowongan.
+func validateListing(listing *entity.Internship_Listing) error {
+	if listing.Seats < 0 {
+		return ErrInvalidSeats
+	}
+	invalidData := screening.Validate(listing.ScreeningCriteria)
+	for field, message := range ranking.Validate(listing.RankingWeights) {
+		invalidData[field] = message
+	}
+	for field, message := range questions.Validate(listing.Questions) {
+		invalidData[field] = message
+	}
+	if listing.StartDate != nil && listing.EndDate != nil && !listing.EndDate.After(*listing.StartDate) {
+		invalidData["end_date"] = "Tanggal selesai harus setelah tanggal mulai"
+	}
+	if listing.ApplicationDeadline != nil && listing.StartDate != nil && listing.ApplicationDeadline.After(*listing.StartDate) {
+		invalidData["application_deadline"] = "Batas pendaftaran tidak boleh setelah tanggal mulai"
+	}
+	if len(invalidData) > 0 {
+		return &ValidationError{Fields: invalidData}
+	}
+	return nil
+}
+
+// ListingUsecase berisi aturan bisnis lowongan magang yang dapat dipanggil dari HTTP, CLI, maupun job.
+type ListingUsecase interface {
+	CreateListing(listing *entity.Internship_Listing) error
+	UpdateListing(id uint, changes *entity.Internship_Listing) (*entity.Internship_Listing, error)
+	ChangeStatus(id uint, to string) (*entity.Internship_Listing, error)
+	DeleteListing(id uint) error
+	GetListings() ([]entity.Internship_Listing, error)
+	GetOpenListings() ([]entity.Internship_Listing, error)
+	GetListing(id uint) (*entity.Internship_Listing, error)
+	SearchListings(search ListingSearch) (*ListingPage, error)
+}
+
+type listingUsecase struct {
+	listings repository.ListingRepository
+	now      func() time.Time
+}
+
+func NewListingUsecase(listings repository.ListingRepository) ListingUsecase {
+	return &listingUsecase{
+		listings: listings,
+		now:      time.Now,
+	}
+}
+
+// CreateListing menyimpan lowongan baru sebagai draft kecuali langsung diminta published.
+func (uc *listingUsecase) CreateListing(listing *entity.Internship_Listing) error {
+	if listing.Quota <= 0 {
+		return ErrInvalidQuota
+	}
+	if listing.Status == "" {
+		listing.Status = constants.ListingDraft
+	}
+	if listing.Status != constants.ListingDraft && listing.Status != constants.ListingPublished {
+		return &ValidationError{Fields: map[string]string{
+			"status": "Lowongan baru hanya dapat berstatus draft atau published",
+		}}
+	}
+	if err := validateListing(listing); err != nil {
+		return err
+	}
+	if listing.Status == constants.ListingPublished {
+		if err := uc.validatePublish(listing); err != nil {
+			return err
+		}
+	}
+	return uc.listings.Create(listing)
+}
+
+// UpdateListing hanya memperbarui field yang diisi lalu mengembalikan data terbaru. Status
+// lowongan hanya dapat diubah melalui ChangeStatus.
+func (uc *listingUsecase) UpdateListing(id uint, changes *entity.Internship_Listing) (*entity.Internship_Listing, error) {
+	if changes.Quota < 0 {
+		return nil, ErrInvalidQuota
+	}
+	if changes.Status != "" {
+		return nil, &ValidationError{Fields: map[string]string{
+			"status": "Status lowongan diubah melalui endpoint status",
+		}}
+	}
+	listing, err := uc.listings.FindByID(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrListingNotFound
+		}
+		return nil, err
+	}
+
+	// Jadwal divalidasi setelah digabung dengan data tersimpan karena perubahan bisa parsial
+	merged := *changes
+	if merged.StartDate == nil {
+		merged.StartDate = listing.StartDate
+	}
+	if merged.EndDate == nil {
+		merged.EndDate = listing.EndDate
+	}
+	if merged.ApplicationDeadline == nil {
+		merged.ApplicationDeadline = listing.ApplicationDeadline
+	}
+	if err := validateListing(&merged); err != nil {
+		return nil, err
+	}
+	if err := uc.listings.Update(id, changes); err != nil {
+		return nil, err
+	}
+	return uc.listings.FindByID(id)
+}
+
+// ChangeStatus memindahkan lowongan ke status to sesuai siklus hidup di package listings/status.
+// Lowongan yang batas pendaftarannya sudah lewat tidak dapat dipublikasikan.
+func (uc *listingUsecase) ChangeStatus(id uint, to string) (*entity.Internship_Listing, error) {
+	listing, err := uc.listings.FindByID(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrListingNotFound
+		}
+		return nil, err
+	}
+	if err := status.Check(listing.Status, to); err != nil {
+		return nil, err
+	}
+	if to == constants.ListingPublished {
+		if err := uc.validatePublish(listing); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.listings.UpdateStatus(id, listing.Status, to); err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			return nil, ErrStatusChanged
+		}
+		return nil, err
+	}
+	listing.Status = to
+	return listing, nil
+}
+
+func (uc *listingUsecase) validatePublish(listing *entity.Internship_Listing) error {
+	if listing.ApplicationDeadline != nil && listing.ApplicationDeadline.Before(uc.now()) {
+		return &ValidationError{Fields: map[string]string{
+			"application_deadline": "Batas pendaftaran sudah lewat, perbarui sebelum mempublikasikan lowongan",
+		}}
+	}
+	return nil
+}
+
+func (uc *listingUsecase) DeleteListing(id uint) error {
+	return uc.listings.Delete(id)
+}
+
+func (uc *listingUsecase) GetListings() ([]entity.Internship_Listing, error) {
+	return uc.listings.FindAll()
+}
+
+// GetOpenListings mengembalikan lowongan yang sedang menerima pendaftaran.
+func (uc *listingUsecase) GetOpenListings() ([]entity.Internship_Listing, error) {
+	return uc.listings.FindOpen(uc.now())
+}
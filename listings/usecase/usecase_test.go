@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"miniproject/constants"
+	"miniproject/entity"
+	"miniproject/repository/memory"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateListingRequiresQuota(t *testing.T) {
+	uc := NewListingUsecase(memory.NewRepositories().Listings)
+
+	assert.ErrorIs(t, uc.CreateListing(&entity.Internship_Listing{Title: "Backend Developer"}), ErrInvalidQuota)
+	assert.NoError(t, uc.CreateListing(&entity.Internship_Listing{Title: "Backend Developer", Quota: 3}))
+}
+
+func TestUpdateListing(t *testing.T) {
+	uc := NewListingUsecase(memory.NewRepositories().Listings)
+	listing := &entity.Internship_Listing{Title: "Backend Developer", Quota: 3}
+	assert.NoError(t, uc.CreateListing(listing))
+
+	updated, err := uc.UpdateListing(listing.ID, &entity.Internship_Listing{Quota: 5})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "Backend Developer", updated.Title)
+		assert.Equal(t, 5, updated.Quota)
+	}
+
+	_, err = uc.UpdateListing(999, &entity.Internship_Listing{Quota: 5})
+	assert.ErrorIs(t, err, ErrListingNotFound)
+}
+
+func TestCreateListingValidatesCriteria(t *testing.T) {
+	uc := NewListingUsecase(memory.NewRepositories().Listings)
+
+	err := uc.CreateListing(&entity.Internship_Listing{
+		Title:             "Backend Developer",
+		Quota:             3,
+		ScreeningCriteria: &entity.ScreeningCriteria{MinGPA: 5},
+	})
+	var validationErr *ValidationError
+	if assert.ErrorAs(t, err, &validationErr) {
+		assert.Contains(t, validationErr.Fields, "screening_criteria.min_gpa")
+	}
+}
+
+func TestCreateListingValidatesQuestions(t *testing.T) {
+	uc := NewListingUsecase(memory.NewRepositories().Listings)
+
+	err := uc.CreateListing(&entity.Internship_Listing{
+		Title:     "Backend Developer",
+		Quota:     3,
+		Questions: []entity.ApplicationQuestion{{Key: "division", Label: "Divisi", Type: constants.QuestionChoice}},
+	})
+	var validationErr *ValidationError
+	if assert.ErrorAs(t, err, &validationErr) {
+		assert.Contains(t, validationErr.Fields, "questions[0].options")
+	}
+}
+
+func TestCreateListingDefaultsToDraft(t *testing.T) {
+	uc := NewListingUsecase(memory.NewRepositories().Listings)
+	listing := &entity.Internship_Listing{Title: "Backend Developer", Quota: 3}
+	assert.NoError(t, uc.CreateListing(listing))
+	assert.Equal(t, constants.ListingDraft, listing.Status)
+
+	var validationErr *ValidationError
+	err := uc.CreateListing(&entity.Internship_Listing{Title: "Data Analyst", Quota: 3, Status: constants.ListingClosed})
+	if assert.ErrorAs(t, err, &validationErr) {
+		assert.Contains(t, validationErr.Fields, "status")
+	}
+}
+
+func TestListingScheduleValidation(t *testing.T) {
+	uc := NewListingUsecase(memory.NewRepositories().Listings)
+	start := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 3, 0)
+	listing := &entity.Internship_Listing{Title: "Backend Developer", Quota: 3, StartDate: &start, EndDate: &end}
+	assert.NoError(t, uc.CreateListing(listing))
+
+	// Perubahan parsial tetap divalidasi terhadap tanggal yang tersimpan
+	deadline := start.AddDate(0, 0, 1)
+	_, err := uc.UpdateListing(listing.ID, &entity.Internship_Listing{ApplicationDeadline: &deadline})
+	var validationErr *ValidationError
+	if assert.ErrorAs(t, err, &validationErr) {
+		assert.Contains(t, validationErr.Fields, "application_deadline")
+	}
+
+	earlyEnd := start.AddDate(0, 0, -1)
+	_, err = uc.UpdateListing(listing.ID, &entity.Internship_Listing{EndDate: &earlyEnd})
+	if assert.ErrorAs(t, err, &validationErr) {
+		assert.Contains(t, validationErr.Fields, "end_date")
+	}
+
+	_, err = uc.UpdateListing(listing.ID, &entity.Internship_Listing{Status: constants.ListingPublished})
+	if assert.ErrorAs(t, err, &validationErr) {
+		assert.Contains(t, validationErr.Fields, "status")
+	}
+}
+
+func TestChangeListingStatus(t *testing.T) {
+	repos := memory.NewRepositories()
+	uc := NewListingUsecase(repos.Listings).(*listingUsecase)
+	now := time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC)
+	uc.now = func() time.Time { return now }
+	deadline := now.Add(24 * time.Hour)
+	listing := &entity.Internship_Listing{Title: "Backend Developer", Quota: 3, ApplicationDeadline: &deadline}
+	assert.NoError(t, uc.CreateListing(listing))
+
+	open, err := uc.GetOpenListings()
+	assert.NoError(t, err)
+	assert.Empty(t, open)
+
+	published, err := uc.ChangeStatus(listing.ID, constants.ListingPublished)
+	if assert.NoError(t, err) {
+		assert.Equal(t, constants.ListingPublished, published.Status)
+	}
+	open, err = uc.GetOpenListings()
+	assert.NoError(t, err)
+	assert.Len(t, open, 1)
+
+	_, err = uc.ChangeStatus(listing.ID, constants.ListingArchived)
+	assert.ErrorIs(t, err, ErrInvalidTransition)
+	_, err = uc.ChangeStatus(listing.ID, constants.ListingClosed)
+	assert.NoError(t, err)
+
+	// Lowongan yang batas pendaftarannya sudah lewat tidak dapat dipublikasikan ulang
+	uc.now = func() time.Time { return deadline.Add(time.Hour) }
+	_, err = uc.ChangeStatus(listing.ID, constants.ListingPublished)
+	var validationErr *ValidationError
+	if assert.ErrorAs(t, err, &validationErr) {
+		assert.Contains(t, validationErr.Fields, "application_deadline")
+	}
+
+	_, err = uc.ChangeStatus(999, constants.ListingPublished)
+	assert.ErrorIs(t, err, ErrListingNotFound)
+}
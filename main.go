@@ -1,24 +1,56 @@
 package main
 
 import (
+	"context"
+	"errors"
+	applicationusecase "miniproject/applications/usecase"
 	"miniproject/infra/config"
 	"miniproject/infra/database"
 	"miniproject/infra/migration"
+	"miniproject/repository"
 	"miniproject/routes"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
 )
 
 func main() {
 	// Inisialisasi konfigurasi
 	cfg := config.InitConfig()
 	db := database.InitDBMysql(cfg)
-	migration.InitMigrationMysql(db)
-	e := routes.InitmyRoutes()
+	if err := migration.InitMigrationMysql(db); err != nil {
+		logrus.Fatal("Migration : ", err.Error())
+	}
+	e := routes.InitmyRoutes(db)
 	
 	
 
+	// Job latar belakang berhenti saat aplikasi menerima sinyal berhenti
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Tawaran magang yang tidak dijawab sampai batas waktunya dilepas secara berkala
+	repos := repository.NewGormRepositories(db)
+	applications := applicationusecase.NewApplicationUsecase(repos.Applications, repos.Listings, repos.Offers)
+	go applicationusecase.RunOfferExpiry(ctx, applications, applicationusecase.OfferExpiryInterval())
+
 	// Mulai server
-	e.Logger.Fatal(e.Start(":" + os.Getenv("SERVERPORT")))
+	go func() {
+		if err := e.Start(":" + os.Getenv("SERVERPORT")); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			e.Logger.Fatal(err)
+		}
+	}()
+
+	<-ctx.Done()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		e.Logger.Fatal(err)
+	}
 }
 
 // Start(":" + os.Getenv("SERVERPORT"))
@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"miniproject/constants"
+	"miniproject/entity"
+	"miniproject/repository"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+const principalContextKey = "principal"
+
+// Auth mengelompokkan middleware dan operasi sesi yang membutuhkan akses data akun dan token.
+type Auth struct {
+	Users    repository.UserRepository
+	Admins   repository.AdminRepository
+	Sessions repository.SessionRepository
+}
+
+func NewAuth(users repository.UserRepository, admins repository.AdminRepository, sessions repository.SessionRepository) *Auth {
+	return &Auth{
+		Users:    users,
+		Admins:   admins,
+		Sessions: sessions,
+	}
+}
+
+// Principal adalah pemilik access token yang sudah dimuat dari basis data.
+// Tepat satu dari User atau Admin terisi sesuai Type.
+type Principal struct {
+	ID       uint
+	Username string
+	Type     string
+	Role     string
+	User     *entity.User
+	Admin    *entity.Admin
+}
+
+// IsAdmin bernilai true jika principal adalah akun admin.
+func (p *Principal) IsAdmin() bool {
+	return p.Type == constants.SubjectAdmin && p.Role == constants.RoleAdmin
+}
+
+// HasRole mengecek role principal. Role admin hanya dimiliki oleh akun di tabel admin dan role
+// user hanya oleh akun di tabel users, apa pun isi kolom role-nya.
+func (p *Principal) HasRole(role string) bool {
+	switch role {
+	case constants.RoleAdmin:
+		return p.IsAdmin()
+	case constants.RoleUser:
+		return p.Type == constants.SubjectUser && p.Role == role
+	}
+	return p.Role == role
+}
+
+// RequireRole memuat principal dari access token sekali per request, menyimpannya
+// ke echo context, dan menolak request jika principal tidak memiliki salah satu role.
+// Harus dipasang setelah JWTMiddleware.
+func (a *Auth) RequireRole(roles ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			principal, err := a.loadPrincipal(c)
+			if err != nil {
+				return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+					"message": constants.ErrUnauthorized,
+				})
+			}
+
+			for _, role := range roles {
+				if principal.HasRole(role) {
+					SetPrincipal(c, principal)
+					return next(c)
+				}
+			}
+
+			return Forbidden(c)
+		}
+	}
+}
+
+// GetPrincipal mengambil principal yang disimpan oleh RequireRole.
+func GetPrincipal(c echo.Context) *Principal {
+	principal, _ := c.Get(principalContextKey).(*Principal)
+	return principal
+}
+
+// SetPrincipal menyimpan principal ke echo context.
+func SetPrincipal(c echo.Context, principal *Principal) {
+	c.Set(principalContextKey, principal)
+}
+
+func (a *Auth) loadPrincipal(c echo.Context) (*Principal, error) {
+	subjectID, username := ExtractToken(c)
+	subjectType, _ := ExtractRole(c)
+
+	switch subjectType {
+	case constants.SubjectAdmin:
+		admin, err := a.Admins.FindByID(subjectID)
+		if err != nil {
+			return nil, err
+		}
+		if admin.Username != username {
+			return nil, echo.ErrUnauthorized
+		}
+		return &Principal{ID: admin.ID, Username: admin.Username, Type: subjectType, Role: admin.Role, Admin: admin}, nil
+	case constants.SubjectUser:
+		user, err := a.Users.FindByID(subjectID)
+		if err != nil {
+			return nil, err
+		}
+		if user.Username != username {
+			return nil, echo.ErrUnauthorized
+		}
+		return &Principal{ID: user.ID, Username: user.Username, Type: subjectType, Role: user.Role, User: user}, nil
+	}
+
+	return nil, echo.ErrUnauthorized
+}
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"miniproject/constants"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHasRole(t *testing.T) {
+	user := &Principal{Type: constants.SubjectUser, Role: constants.RoleUser}
+	assert.True(t, user.HasRole(constants.RoleUser))
+	assert.False(t, user.HasRole(constants.RoleAdmin))
+
+	admin := &Principal{Type: constants.SubjectAdmin, Role: constants.RoleAdmin}
+	assert.True(t, admin.HasRole(constants.RoleAdmin))
+	assert.False(t, admin.HasRole(constants.RoleUser))
+
+	// Baris admin lama dengan role 'user' tidak boleh melewati rute pengguna
+	legacyAdmin := &Principal{Type: constants.SubjectAdmin, Role: constants.RoleUser}
+	assert.False(t, legacyAdmin.HasRole(constants.RoleUser))
+	assert.False(t, legacyAdmin.HasRole(constants.RoleAdmin))
+
+	// Baris users dengan role 'admin' tidak pernah dianggap admin
+	promotedUser := &Principal{Type: constants.SubjectUser, Role: constants.RoleAdmin}
+	assert.False(t, promotedUser.HasRole(constants.RoleAdmin))
+}
@@ -1,39 +1,167 @@
 package middleware
 
 import (
+	"errors"
+	"miniproject/constants"
+	"miniproject/helpers"
 	"os"
+	"strconv"
 	"time"
+
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/joho/godotenv"
 	echojwt "github.com/labstack/echo-jwt/v4"
 	"github.com/labstack/echo/v4"
 )
 
-func JWTMiddleware() echo.MiddlewareFunc {
+const (
+	tokenTypeAccess            = "access"
+	tokenTypeEmailVerification = "email_verification"
+	tokenTypeMFAChallenge      = "mfa_challenge"
+)
+
+var (
+	errInvalidTokenType = errors.New("invalid token type")
+	errTokenRevoked     = errors.New("token has been revoked")
+)
+
+// JWTMiddleware memvalidasi access token dan menolak token yang sudah dicabut
+// (logout, akun dihapus, atau password diganti).
+func (a *Auth) JWTMiddleware() echo.MiddlewareFunc {
 	godotenv.Load(".env")
 	return echojwt.WithConfig(echojwt.Config{
-		SigningKey:    []byte(os.Getenv("SecretKey")),
-		SigningMethod: "HS256",
+		ParseTokenFunc: func(c echo.Context, auth string) (interface{}, error) {
+			token, err := parseToken(auth, tokenTypeAccess)
+			if err != nil {
+				return nil, err
+			}
+			if a.IsTokenRevoked(token.Claims.(jwt.MapClaims)) {
+				return nil, errTokenRevoked
+			}
+			return token, nil
+		},
 	})
 }
 
-func CreateToken(userId uint, username string) (string, error) {
+// accessTokenTTL membaca masa berlaku access token (menit) dari ACCESSTOKENTTL, default 60 menit.
+func accessTokenTTL() time.Duration {
+	minutes, err := strconv.Atoi(os.Getenv("ACCESSTOKENTTL"))
+	if err != nil || minutes <= 0 {
+		return time.Hour * 1
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// CreateToken membuat access token untuk subject (user/admin) beserta role-nya.
+func CreateToken(userId uint, username, subjectType, role string) (string, error) {
+	jti, err := helpers.GenerateToken()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
 	claims := jwt.MapClaims{}
 	claims["authorized"] = true
 	claims["userId"] = userId
 	claims["username"] = username
-	claims["exp"] = time.Now().Add(time.Hour * 1).Unix()
+	claims["sub_type"] = subjectType
+	claims["role"] = role
+	claims["token_type"] = tokenTypeAccess
+	claims["jti"] = jti
+	// iat disimpan dengan presisi milidetik agar dapat dibandingkan dengan waktu pencabutan sesi
+	claims["iat"] = float64(now.UnixMilli()) / 1000
+	claims["exp"] = now.Add(accessTokenTTL()).Unix()
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString([]byte(os.Getenv("SecretKey")))
 }
 
+// CreateEmailVerificationToken membuat token bertanda tangan untuk verifikasi email, berlaku 24 jam.
+// Email ikut disimpan sehingga token otomatis tidak berlaku jika email pengguna diganti.
+func CreateEmailVerificationToken(userId uint, email string) (string, error) {
+	claims := jwt.MapClaims{}
+	claims["userId"] = userId
+	claims["email"] = email
+	claims["token_type"] = tokenTypeEmailVerification
+	claims["exp"] = time.Now().Add(time.Hour * 24).Unix()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(os.Getenv("SecretKey")))
+}
+
+// ParseEmailVerificationToken memvalidasi token verifikasi email dan mengembalikan ID serta email pengguna.
+func ParseEmailVerificationToken(tokenString string) (uint, string, error) {
+	token, err := parseToken(tokenString, tokenTypeEmailVerification)
+	if err != nil {
+		return 0, "", err
+	}
+	claims := token.Claims.(jwt.MapClaims)
+	userId, _ := claims["userId"].(float64)
+	email, _ := claims["email"].(string)
+	return uint(userId), email, nil
+}
+
+// CreateMFAChallengeToken membuat token singkat (5 menit) yang menandakan admin sudah lolos
+// verifikasi password dan hanya dapat ditukar dengan access token setelah kode 2FA valid.
+func CreateMFAChallengeToken(adminId uint) (string, error) {
+	claims := jwt.MapClaims{}
+	claims["userId"] = adminId
+	claims["sub_type"] = constants.SubjectAdmin
+	claims["token_type"] = tokenTypeMFAChallenge
+	claims["exp"] = time.Now().Add(time.Minute * 5).Unix()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(os.Getenv("SecretKey")))
+}
+
+// ParseMFAChallengeToken memvalidasi challenge token dan mengembalikan ID admin.
+func ParseMFAChallengeToken(tokenString string) (uint, error) {
+	token, err := parseToken(tokenString, tokenTypeMFAChallenge)
+	if err != nil {
+		return 0, err
+	}
+	adminId, _ := token.Claims.(jwt.MapClaims)["userId"].(float64)
+	return uint(adminId), nil
+}
+
+// parseToken memvalidasi tanda tangan, masa berlaku, dan jenis token.
+func parseToken(tokenString, tokenType string) (*jwt.Token, error) {
+	token, err := jwt.Parse(tokenString, func(t *jwt.Token) (interface{}, error) {
+		return []byte(os.Getenv("SecretKey")), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return nil, err
+	}
+
+	if token.Claims.(jwt.MapClaims)["token_type"] != tokenType {
+		return nil, errInvalidTokenType
+	}
+	return token, nil
+}
+
 func ExtractToken(e echo.Context) (uint, string) {
-	user := e.Get("user").(*jwt.Token)
-	if user.Valid {
-		claims := user.Claims.(jwt.MapClaims)
-		userId := claims["userId"].(float64)
-		username := claims["username"].(string)
-		return uint(userId), username
-	}
-	return 0, ""
+	claims, ok := extractClaims(e)
+	if !ok {
+		return 0, ""
+	}
+	userId, _ := claims["userId"].(float64)
+	username, _ := claims["username"].(string)
+	return uint(userId), username
+}
+
+// ExtractRole mengambil tipe subject dan role dari access token.
+func ExtractRole(e echo.Context) (string, string) {
+	claims, ok := extractClaims(e)
+	if !ok {
+		return "", ""
+	}
+	subjectType, _ := claims["sub_type"].(string)
+	role, _ := claims["role"].(string)
+	return subjectType, role
+}
+
+func extractClaims(e echo.Context) (jwt.MapClaims, bool) {
+	user, ok := e.Get("user").(*jwt.Token)
+	if !ok || !user.Valid {
+		return nil, false
+	}
+	claims, ok := user.Claims.(jwt.MapClaims)
+	return claims, ok
 }
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"miniproject/constants"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// OwnerOrAdmin mengizinkan akses jika principal adalah admin atau user pemilik resource.
+func OwnerOrAdmin(principal *Principal, ownerID uint) bool {
+	if principal == nil {
+		return false
+	}
+	if principal.IsAdmin() {
+		return true
+	}
+	return principal.Type == constants.SubjectUser && principal.ID == ownerID
+}
+
+// Forbidden mengirim respons 403 yang seragam untuk semua pelanggaran akses.
+func Forbidden(c echo.Context) error {
+	return c.JSON(http.StatusForbidden, map[string]interface{}{
+		"message": constants.ErrForbidden,
+	})
+}
@@ -0,0 +1,159 @@
+package middleware
+
+import (
+	"errors"
+	"miniproject/entity"
+	"miniproject/helpers"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	ErrRefreshTokenInvalid = errors.New("refresh token tidak valid atau sudah kedaluwarsa")
+	ErrRefreshTokenReused  = errors.New("refresh token sudah pernah digunakan, seluruh sesi terkait dicabut")
+)
+
+// refreshTokenTTL membaca masa berlaku refresh token (jam) dari REFRESHTOKENTTL, default 7 hari.
+func refreshTokenTTL() time.Duration {
+	hours, err := strconv.Atoi(os.Getenv("REFRESHTOKENTTL"))
+	if err != nil || hours <= 0 {
+		return time.Hour * 24 * 7
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// IssueRefreshToken membuat refresh token baru untuk subject. familyID kosong berarti sesi login baru.
+func (a *Auth) IssueRefreshToken(subjectType string, subjectID uint, familyID string) (string, error) {
+	raw, err := helpers.GenerateToken()
+	if err != nil {
+		return "", err
+	}
+	if familyID == "" {
+		if familyID, err = helpers.GenerateToken(); err != nil {
+			return "", err
+		}
+	}
+
+	refreshToken := entity.RefreshToken{
+		SubjectType: subjectType,
+		SubjectID:   subjectID,
+		TokenHash:   helpers.HashToken(raw),
+		FamilyID:    familyID,
+		ExpiresAt:   time.Now().Add(refreshTokenTTL()),
+	}
+	if err := a.Sessions.CreateRefreshToken(&refreshToken); err != nil {
+		return "", err
+	}
+	return raw, nil
+}
+
+// RotateRefreshToken mencabut refresh token lama dan menerbitkan penggantinya dalam family yang sama.
+// Pemakaian ulang token yang sudah dicabut dianggap pencurian token sehingga seluruh family dicabut.
+func (a *Auth) RotateRefreshToken(raw string) (*entity.RefreshToken, string, error) {
+	current, err := a.Sessions.FindRefreshToken(helpers.HashToken(raw))
+	if err != nil {
+		return nil, "", ErrRefreshTokenInvalid
+	}
+
+	now := time.Now()
+	if current.RevokedAt != nil {
+		return nil, "", a.revokeReusedFamily(current.FamilyID, now)
+	}
+	if now.After(current.ExpiresAt) {
+		return nil, "", ErrRefreshTokenInvalid
+	}
+
+	next, err := helpers.GenerateToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	rotated, err := a.Sessions.RotateRefreshToken(current.ID, &entity.RefreshToken{
+		SubjectType: current.SubjectType,
+		SubjectID:   current.SubjectID,
+		TokenHash:   helpers.HashToken(next),
+		FamilyID:    current.FamilyID,
+		ExpiresAt:   now.Add(refreshTokenTTL()),
+	}, now)
+	if err != nil {
+		return nil, "", err
+	}
+	if !rotated {
+		// Request paralel lain sudah merotasi token yang sama, diperlakukan sama seperti pemakaian ulang
+		return nil, "", a.revokeReusedFamily(current.FamilyID, now)
+	}
+	return current, next, nil
+}
+
+// revokeReusedFamily mencabut seluruh family dari refresh token yang dipakai ulang lalu mengembalikan
+// ErrRefreshTokenReused, atau error repository jika pencabutan gagal.
+func (a *Auth) revokeReusedFamily(familyID string, now time.Time) error {
+	if err := a.Sessions.RevokeRefreshFamily(familyID, now); err != nil {
+		return err
+	}
+	return ErrRefreshTokenReused
+}
+
+// RevokeRefreshToken mencabut seluruh family dari refresh token milik subject (logout).
+func (a *Auth) RevokeRefreshToken(raw string, subjectType string, subjectID uint) error {
+	refreshToken, err := a.Sessions.FindRefreshToken(helpers.HashToken(raw))
+	if err != nil || refreshToken.SubjectType != subjectType || refreshToken.SubjectID != subjectID {
+		return ErrRefreshTokenInvalid
+	}
+
+	return a.Sessions.RevokeRefreshFamily(refreshToken.FamilyID, time.Now())
+}
+
+// RevokeAccessToken memasukkan access token pada request ini ke denylist hingga masa berlakunya habis.
+func (a *Auth) RevokeAccessToken(c echo.Context) error {
+	claims, ok := extractClaims(c)
+	if !ok {
+		return nil
+	}
+	jti, _ := claims["jti"].(string)
+	subjectType, _ := claims["sub_type"].(string)
+	subjectID, _ := claims["userId"].(float64)
+	exp, _ := claims["exp"].(float64)
+
+	return a.Sessions.CreateRevokedToken(&entity.RevokedToken{
+		JTI:         jti,
+		SubjectType: subjectType,
+		SubjectID:   uint(subjectID),
+		ExpiresAt:   time.Unix(int64(exp), 0),
+	})
+}
+
+// RevokeSubjectSessions mencabut semua refresh token dan access token yang sudah diterbitkan
+// untuk subject, dipakai saat akun dihapus atau password diganti.
+func (a *Auth) RevokeSubjectSessions(subjectType string, subjectID uint) error {
+	now := time.Now()
+	if err := a.Sessions.RevokeSubjectRefreshTokens(subjectType, subjectID, now); err != nil {
+		return err
+	}
+
+	return a.Sessions.CreateRevokedToken(&entity.RevokedToken{
+		SubjectType: subjectType,
+		SubjectID:   subjectID,
+		ExpiresAt:   now.Add(accessTokenTTL()),
+	})
+}
+
+// IsTokenRevoked mengecek denylist untuk jti token maupun pencabutan seluruh sesi subject.
+func (a *Auth) IsTokenRevoked(claims jwt.MapClaims) bool {
+	jti, _ := claims["jti"].(string)
+	subjectType, _ := claims["sub_type"].(string)
+	subjectID, _ := claims["userId"].(float64)
+	iat, _ := claims["iat"].(float64)
+	issuedAt := time.UnixMilli(int64(iat * 1000))
+
+	revoked, err := a.Sessions.IsTokenRevoked(jti, subjectType, uint(subjectID), issuedAt, time.Now())
+	if err != nil {
+		// gagal memeriksa denylist: tolak token daripada meloloskan token yang mungkin sudah dicabut
+		return true
+	}
+	return revoked
+}
@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"miniproject/constants"
+	"miniproject/entity"
+	"miniproject/helpers"
+	"miniproject/repository"
+	"miniproject/repository/memory"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestAuth() *Auth {
+	repos := memory.NewRepositories()
+	return NewAuth(repos.Users, repos.Admins, repos.Sessions)
+}
+
+// isRevoked memeriksa apakah refresh token mentah sudah dicabut.
+func isRevoked(t *testing.T, a *Auth, raw string) bool {
+	token, err := a.Sessions.FindRefreshToken(helpers.HashToken(raw))
+	if !assert.NoError(t, err) {
+		return false
+	}
+	return token.RevokedAt != nil
+}
+
+func TestRotateRefreshToken(t *testing.T) {
+	a := newTestAuth()
+	first, err := a.IssueRefreshToken(constants.SubjectUser, 1, "")
+	assert.NoError(t, err)
+
+	current, second, err := a.RotateRefreshToken(first)
+	if assert.NoError(t, err) {
+		assert.Equal(t, uint(1), current.SubjectID)
+		assert.NotEqual(t, first, second)
+	}
+	assert.True(t, isRevoked(t, a, first))
+	assert.False(t, isRevoked(t, a, second))
+
+	_, _, err = a.RotateRefreshToken("tidak-dikenal")
+	assert.ErrorIs(t, err, ErrRefreshTokenInvalid)
+}
+
+func TestRotateRefreshTokenReuseRevokesFamily(t *testing.T) {
+	a := newTestAuth()
+	first, _ := a.IssueRefreshToken(constants.SubjectUser, 1, "")
+	_, second, err := a.RotateRefreshToken(first)
+	assert.NoError(t, err)
+	other, _ := a.IssueRefreshToken(constants.SubjectUser, 1, "")
+
+	// Token lama yang dipakai ulang mencabut seluruh family, tetapi tidak sesi login lain
+	_, _, err = a.RotateRefreshToken(first)
+	assert.ErrorIs(t, err, ErrRefreshTokenReused)
+	assert.True(t, isRevoked(t, a, second))
+	assert.False(t, isRevoked(t, a, other))
+}
+
+// racingSessions mensimulasikan request paralel yang merotasi token yang sama lebih dulu.
+type racingSessions struct {
+	repository.SessionRepository
+	winner *entity.RefreshToken
+}
+
+func (r *racingSessions) RotateRefreshToken(currentID uint, next *entity.RefreshToken, now time.Time) (bool, error) {
+	winner := *next
+	winner.TokenHash = helpers.HashToken("pemenang")
+	if _, err := r.SessionRepository.RotateRefreshToken(currentID, &winner, now); err != nil {
+		return false, err
+	}
+	r.winner = &winner
+	return r.SessionRepository.RotateRefreshToken(currentID, next, now)
+}
+
+func TestRotateRefreshTokenRaceRevokesFamily(t *testing.T) {
+	a := newTestAuth()
+	sessions := &racingSessions{SessionRepository: a.Sessions}
+	a.Sessions = sessions
+	first, _ := a.IssueRefreshToken(constants.SubjectUser, 1, "")
+
+	_, _, err := a.RotateRefreshToken(first)
+	assert.ErrorIs(t, err, ErrRefreshTokenReused)
+	if assert.NotNil(t, sessions.winner) {
+		assert.True(t, isRevoked(t, a, "pemenang"))
+	}
+}
+
+func TestRotateRefreshTokenExpired(t *testing.T) {
+	a := newTestAuth()
+	raw := "kedaluwarsa"
+	assert.NoError(t, a.Sessions.CreateRefreshToken(&entity.RefreshToken{
+		SubjectType: constants.SubjectUser,
+		SubjectID:   1,
+		TokenHash:   helpers.HashToken(raw),
+		FamilyID:    "family",
+		ExpiresAt:   time.Now().Add(-time.Minute),
+	}))
+
+	_, _, err := a.RotateRefreshToken(raw)
+	assert.ErrorIs(t, err, ErrRefreshTokenInvalid)
+}
+
+func TestRevokeRefreshTokenChecksOwner(t *testing.T) {
+	a := newTestAuth()
+	raw, _ := a.IssueRefreshToken(constants.SubjectUser, 1, "")
+
+	assert.ErrorIs(t, a.RevokeRefreshToken(raw, constants.SubjectUser, 2), ErrRefreshTokenInvalid)
+	assert.False(t, isRevoked(t, a, raw))
+	assert.NoError(t, a.RevokeRefreshToken(raw, constants.SubjectUser, 1))
+	assert.True(t, isRevoked(t, a, raw))
+}
+
+func TestRevokeSubjectSessions(t *testing.T) {
+	a := newTestAuth()
+	raw, _ := a.IssueRefreshToken(constants.SubjectUser, 1, "")
+	issuedAt := float64(time.Now().Add(-time.Second).UnixMilli()) / 1000
+	claims := jwt.MapClaims{"jti": "akses", "sub_type": constants.SubjectUser, "userId": float64(1), "iat": issuedAt}
+	otherClaims := jwt.MapClaims{"jti": "lain", "sub_type": constants.SubjectUser, "userId": float64(2), "iat": issuedAt}
+	assert.False(t, a.IsTokenRevoked(claims))
+
+	// Password diganti atau akun dihapus: semua token subject yang sudah terbit ikut dicabut
+	assert.NoError(t, a.RevokeSubjectSessions(constants.SubjectUser, 1))
+	assert.True(t, a.IsTokenRevoked(claims))
+	assert.False(t, a.IsTokenRevoked(otherClaims))
+	assert.True(t, isRevoked(t, a, raw))
+
+	// Token yang diterbitkan setelah pencabutan tetap berlaku
+	claims["iat"] = float64(time.Now().Add(time.Second).UnixMilli()) / 1000
+	assert.False(t, a.IsTokenRevoked(claims))
+}
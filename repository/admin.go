@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"miniproject/entity"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AdminRepository interface {
+	Create(admin *entity.Admin) error
+	// CreateFirst menyimpan admin pertama (bootstrap) secara atomik. Mengembalikan ErrConflict jika
+	// sudah ada admin atau bootstrap lain sedang/sudah berjalan.
+	CreateFirst(admin *entity.Admin) error
+	FindByID(id uint) (*entity.Admin, error)
+	FindByUsername(username string) (*entity.Admin, error)
+	FindByEmail(email string) (*entity.Admin, error)
+	Save(admin *entity.Admin) error
+	Count() (int64, error)
+
+	// UseTOTPStep mencatat step TOTP terakhir yang dipakai. Mengembalikan false jika step
+	// tersebut (atau yang lebih baru) sudah pernah dipakai, sehingga kode tidak bisa diputar ulang.
+	UseTOTPStep(adminID uint, step int64) (bool, error)
+	// EnableTOTP mengaktifkan 2FA dan mengganti seluruh kode pemulihan dengan hash yang diberikan.
+	EnableTOTP(adminID uint, recoveryCodeHashes []string) error
+	// DisableTOTP menonaktifkan 2FA, menghapus secret, dan menghapus semua kode pemulihan.
+	DisableTOTP(adminID uint) error
+	// UseRecoveryCode menandai kode pemulihan sebagai terpakai. Mengembalikan false jika kode
+	// tidak ada atau sudah pernah dipakai.
+	UseRecoveryCode(adminID uint, codeHash string) (bool, error)
+}
+
+type adminRepository struct {
+	db *gorm.DB
+}
+
+func NewAdminRepository(db *gorm.DB) AdminRepository {
+	return &adminRepository{db: db}
+}
+
+func (r *adminRepository) Create(admin *entity.Admin) error {
+	return r.db.Create(admin).Error
+}
+
+func (r *adminRepository) CreateFirst(admin *entity.Admin) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Bootstrap paralel menunggu baris penanda ini di-commit lalu tidak menyisipkan apa pun
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&entity.AdminBootstrap{ID: 1})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrConflict
+		}
+
+		// Basis data lama bisa sudah memiliki admin tanpa baris penanda
+		var count int64
+		if err := tx.Model(&entity.Admin{}).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrConflict
+		}
+		return tx.Create(admin).Error
+	})
+}
+
+func (r *adminRepository) FindByID(id uint) (*entity.Admin, error) {
+	admin := entity.Admin{}
+	if err := r.db.First(&admin, id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &admin, nil
+}
+
+func (r *adminRepository) FindByUsername(username string) (*entity.Admin, error) {
+	admin := entity.Admin{}
+	if err := r.db.Where("username = ?", username).First(&admin).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &admin, nil
+}
+
+func (r *adminRepository) FindByEmail(email string) (*entity.Admin, error) {
+	admin := entity.Admin{}
+	if err := r.db.Where("email = ?", email).First(&admin).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &admin, nil
+}
+
+func (r *adminRepository) Save(admin *entity.Admin) error {
+	return r.db.Save(admin).Error
+}
+
+func (r *adminRepository) Count() (int64, error) {
+	var count int64
+	err := r.db.Model(&entity.Admin{}).Count(&count).Error
+	return count, err
+}
+
+func (r *adminRepository) UseTOTPStep(adminID uint, step int64) (bool, error) {
+	result := r.db.Model(&entity.Admin{}).
+		Where("id = ? AND totp_last_used_step < ?", adminID, step).
+		Update("totp_last_used_step", step)
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *adminRepository) EnableTOTP(adminID uint, recoveryCodeHashes []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&entity.Admin{}).Where("id = ?", adminID).Update("totp_enabled", true).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("admin_id = ?", adminID).Delete(&entity.AdminRecoveryCode{}).Error; err != nil {
+			return err
+		}
+		for _, hash := range recoveryCodeHashes {
+			if err := tx.Create(&entity.AdminRecoveryCode{AdminID: adminID, CodeHash: hash}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *adminRepository) DisableTOTP(adminID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&entity.Admin{}).Where("id = ?", adminID).Updates(map[string]interface{}{
+			"totp_enabled":        false,
+			"totp_secret":         "",
+			"totp_last_used_step": 0,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Unscoped().Where("admin_id = ?", adminID).Delete(&entity.AdminRecoveryCode{}).Error
+	})
+}
+
+func (r *adminRepository) UseRecoveryCode(adminID uint, codeHash string) (bool, error) {
+	result := r.db.Model(&entity.AdminRecoveryCode{}).
+		Where("admin_id = ? AND code_hash = ? AND used_at IS NULL", adminID, codeHash).
+		Update("used_at", time.Now())
+	return result.RowsAffected == 1, result.Error
+}
@@ -0,0 +1,440 @@
+package repository
+
+import (
+	"errors"
+	"miniproject/constants"
+	"miniproject/entity"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ApplicationRepository interface {
+	Create(application *entity.Internship_ApplicationForm) error
+	// FindByID dan FindByListingID ikut memuat dokumen formulir untuk penyaringan dan pemeringkatan.
+	FindByID(id uint) (*entity.Internship_ApplicationForm, error)
+	FindAll() ([]entity.Internship_ApplicationForm, error)
+	FindByListingID(listingID uint) ([]entity.Internship_ApplicationForm, error)
+	Save(application *entity.Internship_ApplicationForm) error
+	// Submit menyimpan formulir, mengurangi kuota lowongan secara atomik, dan mencatat
+	// kandidat dalam satu transaksi. Mengembalikan ErrConflict jika pengguna masih memiliki
+	// formulir yang belum dibatalkan pada lowongan yang sama, ErrLimitExceeded jika pengguna
+	// sudah memiliki maxActive formulir aktif, dan ErrQuotaFull jika kuota sudah habis.
+	Submit(application *entity.Internship_ApplicationForm, maxActive int) error
+	// CreateDraft menyimpan formulir berstatus draft tanpa memakai kuota. Mengembalikan
+	// ErrConflict jika pengguna masih memiliki formulir yang belum dibatalkan pada lowongan yang sama.
+	CreateDraft(application *entity.Internship_ApplicationForm) error
+	// UpdateDraft menyimpan Nim, GPA, jenjang pendidikan, dan jawaban formulir. Mengembalikan
+	// ErrConflict jika formulir sudah bukan draft.
+	UpdateDraft(application *entity.Internship_ApplicationForm) error
+	// SubmitDraft mengajukan draft menjadi pending beserta isinya, mengurangi kuota lowongan, dan
+	// mencatat kandidat dalam satu transaksi. Mengembalikan ErrLimitExceeded dan ErrQuotaFull
+	// seperti Submit, atau ErrConflict jika formulir sudah bukan draft.
+	SubmitDraft(application *entity.Internship_ApplicationForm, maxActive int) error
+	// Transition mengubah status formulir dari history.FromStatus ke history.ToStatus dan
+	// mencatat history dalam satu transaksi. Pembatalan juga mengembalikan satu slot kuota ke
+	// lowongannya kecuali untuk draft yang belum memakai kuota. Mengembalikan ErrConflict jika status formulir sudah diubah proses lain.
+	Transition(application *entity.Internship_ApplicationForm, history *entity.ApplicationStatusHistory) error
+	// TransitionMany menerapkan semua perubahan dalam satu transaksi; jika satu perubahan gagal
+	// tidak ada perubahan yang disimpan. Penerimaan kandidat mengunci baris lowongan dan
+	// mengembalikan ErrSeatsFull jika semua kursinya sudah terisi.
+	TransitionMany(changes []StatusChange) error
+	FindStatusHistory(applicationID uint) ([]entity.ApplicationStatusHistory, error)
+	// PromoteNext menerima kandidat daftar tunggu dengan peringkat terbaik pada lowongan jika
+	// masih ada kursi kosong dan menyimpan offer untuknya. Baris lowongan dikunci sehingga
+	// pembatalan bersamaan tidak mempromosikan kandidat melebihi jumlah kursi.
+	// Mengembalikan ErrNotFound jika tidak ada kursi kosong atau daftar tunggu kosong.
+	PromoteNext(listingID uint, offer *entity.Offer, history *entity.ApplicationStatusHistory) (*entity.Internship_ApplicationForm, error)
+	// SetAnswer menyimpan answer ke formulir, menggantikan jawaban lama dengan key yang sama.
+	// Baris formulir dikunci sehingga jawaban yang disimpan bersamaan tidak saling menimpa.
+	SetAnswer(applicationID uint, answer entity.ApplicationAnswer) error
+}
+
+// StatusChange adalah satu perubahan status formulir untuk TransitionMany. Peringkat dan skor
+// formulir ikut disimpan. Jika History.FromStatus sama dengan History.ToStatus hanya peringkat
+// dan skor yang diperbarui tanpa mencatat riwayat. Offer yang diisi disimpan untuk formulir
+// dalam transaksi yang sama; pembatalan menarik offer formulir yang belum dijawab.
+type StatusChange struct {
+	Application *entity.Internship_ApplicationForm
+	History     *entity.ApplicationStatusHistory
+	Offer       *entity.Offer
+}
+
+type applicationRepository struct {
+	db *gorm.DB
+}
+
+func NewApplicationRepository(db *gorm.DB) ApplicationRepository {
+	return &applicationRepository{db: db}
+}
+
+func (r *applicationRepository) Create(application *entity.Internship_ApplicationForm) error {
+	return r.db.Create(application).Error
+}
+
+func (r *applicationRepository) FindByID(id uint) (*entity.Internship_ApplicationForm, error) {
+	application := entity.Internship_ApplicationForm{}
+	if err := r.db.Preload("Documents").First(&application, id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &application, nil
+}
+
+func (r *applicationRepository) FindAll() ([]entity.Internship_ApplicationForm, error) {
+	var applications []entity.Internship_ApplicationForm
+	err := r.db.Find(&applications).Error
+	return applications, err
+}
+
+func (r *applicationRepository) FindByListingID(listingID uint) ([]entity.Internship_ApplicationForm, error) {
+	var applications []entity.Internship_ApplicationForm
+	err := r.db.Preload("Documents").Where("internship_listing_id = ?", listingID).Order("id").Find(&applications).Error
+	return applications, err
+}
+
+func (r *applicationRepository) Save(application *entity.Internship_ApplicationForm) error {
+	// Dokumen dikelola DocumentRepository dan tidak ikut disimpan ulang
+	return r.db.Omit("Documents").Save(application).Error
+}
+
+func (r *applicationRepository) Submit(application *entity.Internship_ApplicationForm, maxActive int) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockApplicant(tx, application); err != nil {
+			return err
+		}
+		if err := checkDuplicate(tx, application); err != nil {
+			return err
+		}
+		if err := reserveQuota(tx, application, maxActive); err != nil {
+			return err
+		}
+
+		if err := tx.Create(application).Error; err != nil {
+			return err
+		}
+		if err := tx.Create(&entity.Selected_Candidate{InternshipApplicationFormID: application.ID}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&entity.ApplicationStatusHistory{
+			InternshipApplicationFormID: application.ID,
+			ToStatus:                    application.Status,
+			ChangedByType:               constants.SubjectUser,
+			ChangedByID:                 application.UserID,
+		}).Error
+	})
+}
+
+func (r *applicationRepository) CreateDraft(application *entity.Internship_ApplicationForm) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockApplicant(tx, application); err != nil {
+			return err
+		}
+		if err := checkDuplicate(tx, application); err != nil {
+			return err
+		}
+		return tx.Create(application).Error
+	})
+}
+
+// draftColumns adalah kolom formulir yang dapat diubah selama masih berupa draft.
+var draftColumns = []string{"nim", "gpa", "education_level", "answers"}
+
+func (r *applicationRepository) UpdateDraft(application *entity.Internship_ApplicationForm) error {
+	result := r.db.Model(&entity.Internship_ApplicationForm{}).
+		Where("id = ? AND status = ?", application.ID, constants.StatusDraft).
+		Select(draftColumns).
+		Updates(application)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		// MySQL tidak menghitung baris yang nilainya tidak berubah, jadi periksa status terkini
+		current := entity.Internship_ApplicationForm{}
+		if err := r.db.First(&current, application.ID).Error; err != nil {
+			return notFound(err)
+		}
+		if current.Status != constants.StatusDraft {
+			return ErrConflict
+		}
+	}
+	return nil
+}
+
+func (r *applicationRepository) SubmitDraft(application *entity.Internship_ApplicationForm, maxActive int) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := lockApplicant(tx, application); err != nil {
+			return err
+		}
+		if err := reserveQuota(tx, application, maxActive); err != nil {
+			return err
+		}
+
+		submitted := *application
+		submitted.Status = constants.StatusPending
+		result := tx.Model(&entity.Internship_ApplicationForm{}).
+			Where("id = ? AND status = ?", application.ID, constants.StatusDraft).
+			Select(append([]string{"status"}, draftColumns...)).
+			Updates(&submitted)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrConflict
+		}
+
+		if err := tx.Create(&entity.Selected_Candidate{InternshipApplicationFormID: application.ID}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&entity.ApplicationStatusHistory{
+			InternshipApplicationFormID: application.ID,
+			FromStatus:                  constants.StatusDraft,
+			ToStatus:                    constants.StatusPending,
+			ChangedByType:               constants.SubjectUser,
+			ChangedByID:                 application.UserID,
+		}).Error
+	})
+	if err != nil {
+		return err
+	}
+	application.Status = constants.StatusPending
+	return nil
+}
+
+// lockApplicant mengunci baris pengguna agar pengajuan paralel milik pengguna yang sama
+// diperiksa berurutan.
+func lockApplicant(tx *gorm.DB, application *entity.Internship_ApplicationForm) error {
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", application.UserID).
+		Find(&entity.User{}).Error
+}
+
+// checkDuplicate mengembalikan ErrConflict jika pengguna masih memiliki formulir (termasuk draft)
+// yang belum dibatalkan pada lowongan yang sama.
+func checkDuplicate(tx *gorm.DB, application *entity.Internship_ApplicationForm) error {
+	var duplicates int64
+	err := tx.Model(&entity.Internship_ApplicationForm{}).
+		Where("user_id = ? AND internship_listing_id = ? AND is_canceled = ?",
+			application.UserID, application.InternshipListingID, false).
+		Count(&duplicates).Error
+	if err != nil {
+		return err
+	}
+	if duplicates > 0 {
+		return ErrConflict
+	}
+	return nil
+}
+
+// reserveQuota memeriksa batas formulir aktif pengguna (draft tidak dihitung) lalu mengurangi
+// satu kuota lowongan.
+func reserveQuota(tx *gorm.DB, application *entity.Internship_ApplicationForm, maxActive int) error {
+	var active int64
+	err := tx.Model(&entity.Internship_ApplicationForm{}).
+		Where("user_id = ? AND is_canceled = ? AND status NOT IN ?",
+			application.UserID, false, []string{constants.StatusRejected, constants.StatusDraft}).
+		Count(&active).Error
+	if err != nil {
+		return err
+	}
+	if active >= int64(maxActive) {
+		return ErrLimitExceeded
+	}
+
+	// Update bersyarat mengunci baris lowongan sehingga kuota tidak pernah negatif
+	result := tx.Model(&entity.Internship_Listing{}).
+		Where("id = ? AND quota > 0", application.InternshipListingID).
+		Update("quota", gorm.Expr("quota - 1"))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		if err := tx.First(&entity.Internship_Listing{}, application.InternshipListingID).Error; err != nil {
+			return notFound(err)
+		}
+		return ErrQuotaFull
+	}
+	return nil
+}
+
+func (r *applicationRepository) Transition(application *entity.Internship_ApplicationForm, history *entity.ApplicationStatusHistory) error {
+	return r.TransitionMany([]StatusChange{{Application: application, History: history}})
+}
+
+func (r *applicationRepository) TransitionMany(changes []StatusChange) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, change := range changes {
+			if err := transition(tx, change); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, change := range changes {
+		change.Application.Status = change.History.ToStatus
+		change.Application.IsCanceled = change.History.ToStatus == constants.StatusCanceled
+	}
+	return nil
+}
+
+func transition(tx *gorm.DB, change StatusChange) error {
+	application, history := change.Application, change.History
+	canceled := history.ToStatus == constants.StatusCanceled
+
+	if history.ToStatus == constants.StatusAccepted && history.FromStatus != constants.StatusAccepted {
+		if err := reserveSeat(tx, application.InternshipListingID); err != nil {
+			return err
+		}
+	}
+
+	// Update bersyarat memastikan dua perubahan paralel tidak saling menimpa
+	result := tx.Model(&entity.Internship_ApplicationForm{}).
+		Where("id = ? AND status = ?", application.ID, history.FromStatus).
+		Updates(map[string]interface{}{
+			"status":          history.ToStatus,
+			"is_canceled":     canceled,
+			"selection_rank":  application.SelectionRank,
+			"selection_score": application.SelectionScore,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		// MySQL tidak menghitung baris yang nilainya tidak berubah, jadi periksa status terkini
+		current := entity.Internship_ApplicationForm{}
+		if err := tx.First(&current, application.ID).Error; err != nil {
+			return notFound(err)
+		}
+		if current.Status != history.FromStatus {
+			return ErrConflict
+		}
+	}
+	if history.FromStatus == history.ToStatus {
+		return nil
+	}
+
+	if canceled && history.FromStatus != constants.StatusDraft {
+		// Lowongan yang sudah dihapus tidak perlu dikembalikan kuotanya
+		err := tx.Model(&entity.Internship_Listing{}).
+			Where("id = ?", application.InternshipListingID).
+			Update("quota", gorm.Expr("quota + 1")).Error
+		if err != nil {
+			return err
+		}
+	}
+	if canceled {
+		err := tx.Model(&entity.Offer{}).
+			Where("internship_application_form_id = ? AND status = ?", application.ID, constants.OfferPending).
+			Update("status", constants.OfferWithdrawn).Error
+		if err != nil {
+			return err
+		}
+	}
+	if change.Offer != nil {
+		if err := createOffer(tx, application, change.Offer); err != nil {
+			return err
+		}
+	}
+
+	history.InternshipApplicationFormID = application.ID
+	return tx.Create(history).Error
+}
+
+// reserveSeat mengunci baris lowongan lalu memastikan masih ada kursi untuk satu kandidat lagi,
+// sehingga penerimaan yang bersamaan tidak melebihi jumlah kursi.
+func reserveSeat(tx *gorm.DB, listingID uint) error {
+	listing := entity.Internship_Listing{}
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&listing, listingID).Error
+	if err != nil {
+		return notFound(err)
+	}
+
+	var accepted int64
+	err = tx.Model(&entity.Internship_ApplicationForm{}).
+		Where("internship_listing_id = ? AND status = ?", listingID, constants.StatusAccepted).
+		Count(&accepted).Error
+	if err != nil {
+		return err
+	}
+	if accepted >= int64(listing.Seats) {
+		return ErrSeatsFull
+	}
+	return nil
+}
+
+func createOffer(tx *gorm.DB, application *entity.Internship_ApplicationForm, offer *entity.Offer) error {
+	offer.InternshipApplicationFormID = application.ID
+	offer.InternshipListingID = application.InternshipListingID
+	offer.UserID = application.UserID
+	offer.Status = constants.OfferPending
+	return tx.Create(offer).Error
+}
+
+func (r *applicationRepository) FindStatusHistory(applicationID uint) ([]entity.ApplicationStatusHistory, error) {
+	var history []entity.ApplicationStatusHistory
+	err := r.db.Where("internship_application_form_id = ?", applicationID).Order("id").Find(&history).Error
+	return history, err
+}
+
+func (r *applicationRepository) PromoteNext(listingID uint, offer *entity.Offer, history *entity.ApplicationStatusHistory) (*entity.Internship_ApplicationForm, error) {
+	promoted := entity.Internship_ApplicationForm{}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := reserveSeat(tx, listingID); err != nil {
+			if errors.Is(err, ErrSeatsFull) {
+				return ErrNotFound
+			}
+			return err
+		}
+
+		// Kandidat tanpa peringkat (ditambahkan manual) diletakkan di akhir daftar tunggu
+		err := tx.Where("internship_listing_id = ? AND status = ?", listingID, constants.StatusWaitlisted).
+			Order("selection_rank = 0, selection_rank, id").
+			First(&promoted).Error
+		if err != nil {
+			return notFound(err)
+		}
+
+		history.FromStatus = constants.StatusWaitlisted
+		history.ToStatus = constants.StatusAccepted
+		return transition(tx, StatusChange{Application: &promoted, History: history, Offer: offer})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	promoted.Status = constants.StatusAccepted
+	return &promoted, nil
+}
+
+func (r *applicationRepository) SetAnswer(applicationID uint, answer entity.ApplicationAnswer) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		application := entity.Internship_ApplicationForm{}
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&application, applicationID).Error
+		if err != nil {
+			return notFound(err)
+		}
+		return tx.Model(&entity.Internship_ApplicationForm{}).
+			Where("id = ?", applicationID).
+			Select("answers").
+			Updates(&entity.Internship_ApplicationForm{Answers: replaceAnswer(application.Answers, answer)}).Error
+	})
+}
+
+// replaceAnswer mengganti jawaban dengan key yang sama atau menambahkannya di akhir.
+func replaceAnswer(answers []entity.ApplicationAnswer, answer entity.ApplicationAnswer) []entity.ApplicationAnswer {
+	result := make([]entity.ApplicationAnswer, 0, len(answers)+1)
+	replaced := false
+	for _, existing := range answers {
+		if existing.Key == answer.Key {
+			existing, replaced = answer, true
+		}
+		result = append(result, existing)
+	}
+	if !replaced {
+		result = append(result, answer)
+	}
+	return result
+}
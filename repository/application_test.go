@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"errors"
+	"miniproject/constants"
+	"miniproject/entity"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestDB membuka basis data SQLite sementara dengan tabel yang dipakai pengajuan formulir.
+// SQLite menjalankan transaksi tulis satu per satu (BEGIN IMMEDIATE), jadi yang diuji di sini
+// adalah update bersyarat dan pemeriksaan di dalam transaksi, bukan penguncian baris MySQL.
+func newTestDB(t *testing.T) *gorm.DB {
+	dsn := filepath.Join(t.TempDir(), "test.db") + "?_busy_timeout=10000&_txlock=immediate"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	// Kolom enum milik users hanya dikenal MySQL; lockApplicant cukup membaca id dan deleted_at
+	err = db.Exec("CREATE TABLE users (id integer PRIMARY KEY, deleted_at datetime)").Error
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	err = db.AutoMigrate(
+		&entity.Internship_Listing{},
+		&entity.Internship_ApplicationForm{},
+		&entity.Selected_Candidate{},
+		&entity.ApplicationStatusHistory{},
+	)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return db
+}
+
+func submitConcurrently(repo ApplicationRepository, forms []*entity.Internship_ApplicationForm) []error {
+	errs := make([]error, len(forms))
+	var wg sync.WaitGroup
+	for i, form := range forms {
+		wg.Add(1)
+		go func(i int, form *entity.Internship_ApplicationForm) {
+			defer wg.Done()
+			errs[i] = repo.Submit(form, 3)
+		}(i, form)
+	}
+	wg.Wait()
+	return errs
+}
+
+func TestSubmitConcurrentDoesNotOversubscribe(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewApplicationRepository(db)
+	listing := entity.Internship_Listing{Title: "Backend Developer", Quota: 3, Status: constants.ListingPublished}
+	assert.NoError(t, db.Create(&listing).Error)
+
+	forms := make([]*entity.Internship_ApplicationForm, 10)
+	for i := range forms {
+		forms[i] = &entity.Internship_ApplicationForm{
+			Nim:                 "123456",
+			GPA:                 3.5,
+			EducationLevel:      "S1",
+			Status:              constants.StatusPending,
+			UserID:              uint(i + 1),
+			InternshipListingID: listing.ID,
+		}
+	}
+
+	submitted := 0
+	for _, err := range submitConcurrently(repo, forms) {
+		switch {
+		case err == nil:
+			submitted++
+		case !errors.Is(err, ErrQuotaFull):
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	assert.Equal(t, 3, submitted)
+
+	stored := entity.Internship_Listing{}
+	assert.NoError(t, db.First(&stored, listing.ID).Error)
+	assert.Equal(t, 0, stored.Quota)
+
+	var applications, candidates int64
+	assert.NoError(t, db.Model(&entity.Internship_ApplicationForm{}).Count(&applications).Error)
+	assert.NoError(t, db.Model(&entity.Selected_Candidate{}).Count(&candidates).Error)
+	assert.Equal(t, int64(3), applications)
+	assert.Equal(t, int64(3), candidates)
+}
+
+func TestSubmitConcurrentSameApplicant(t *testing.T) {
+	db := newTestDB(t)
+	repo := NewApplicationRepository(db)
+	listing := entity.Internship_Listing{Title: "Backend Developer", Quota: 5, Status: constants.ListingPublished}
+	assert.NoError(t, db.Create(&listing).Error)
+
+	forms := make([]*entity.Internship_ApplicationForm, 5)
+	for i := range forms {
+		forms[i] = &entity.Internship_ApplicationForm{
+			Nim:                 "123456",
+			GPA:                 3.5,
+			EducationLevel:      "S1",
+			Status:              constants.StatusPending,
+			UserID:              1,
+			InternshipListingID: listing.ID,
+		}
+	}
+
+	submitted := 0
+	for _, err := range submitConcurrently(repo, forms) {
+		switch {
+		case err == nil:
+			submitted++
+		case !errors.Is(err, ErrConflict):
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	assert.Equal(t, 1, submitted)
+
+	stored := entity.Internship_Listing{}
+	assert.NoError(t, db.First(&stored, listing.ID).Error)
+	assert.Equal(t, 4, stored.Quota)
+}
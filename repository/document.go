@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"miniproject/entity"
+
+	"gorm.io/gorm"
+)
+
+type DocumentRepository interface {
+	Create(document *entity.ApplicationDocument) error
+	FindByID(id uint) (*entity.ApplicationDocument, error)
+	FindByApplicationID(applicationID uint) ([]entity.ApplicationDocument, error)
+	Delete(id uint) error
+}
+
+type documentRepository struct {
+	db *gorm.DB
+}
+
+func NewDocumentRepository(db *gorm.DB) DocumentRepository {
+	return &documentRepository{db: db}
+}
+
+func (r *documentRepository) Create(document *entity.ApplicationDocument) error {
+	return r.db.Create(document).Error
+}
+
+func (r *documentRepository) FindByID(id uint) (*entity.ApplicationDocument, error) {
+	document := entity.ApplicationDocument{}
+	if err := r.db.First(&document, id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &document, nil
+}
+
+func (r *documentRepository) FindByApplicationID(applicationID uint) ([]entity.ApplicationDocument, error) {
+	var documents []entity.ApplicationDocument
+	err := r.db.Where("internship_application_form_id = ?", applicationID).Order("id").Find(&documents).Error
+	return documents, err
+}
+
+func (r *documentRepository) Delete(id uint) error {
+	return r.db.Delete(&entity.ApplicationDocument{}, id).Error
+}
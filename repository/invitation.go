@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"miniproject/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type InvitationRepository interface {
+	// Create menyimpan undangan baru dan mencabut undangan aktif lain untuk email yang sama.
+	Create(invitation *entity.AdminInvitation, now time.Time) error
+	FindAll() ([]entity.AdminInvitation, error)
+	// Revoke mencabut undangan yang belum diterima. Mengembalikan false jika undangan tidak aktif.
+	Revoke(id uint, now time.Time) (bool, error)
+	// Accept memakai undangan (sekali pakai) dan membuat akun admin dengan email dan role dari
+	// undangan dalam satu transaksi. Mengembalikan ErrNotFound jika undangan tidak berlaku dan
+	// ErrConflict jika email atau username sudah dipakai.
+	Accept(tokenHash string, admin *entity.Admin, now time.Time) error
+}
+
+type invitationRepository struct {
+	db *gorm.DB
+}
+
+func NewInvitationRepository(db *gorm.DB) InvitationRepository {
+	return &invitationRepository{db: db}
+}
+
+func (r *invitationRepository) Create(invitation *entity.AdminInvitation, now time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&entity.AdminInvitation{}).
+			Where("email = ? AND accepted_at IS NULL AND revoked_at IS NULL", invitation.Email).
+			Update("revoked_at", now).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(invitation).Error
+	})
+}
+
+func (r *invitationRepository) FindAll() ([]entity.AdminInvitation, error) {
+	var invitations []entity.AdminInvitation
+	err := r.db.Order("created_at desc").Find(&invitations).Error
+	return invitations, err
+}
+
+func (r *invitationRepository) Revoke(id uint, now time.Time) (bool, error) {
+	result := r.db.Model(&entity.AdminInvitation{}).
+		Where("id = ? AND accepted_at IS NULL AND revoked_at IS NULL", id).
+		Update("revoked_at", now)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *invitationRepository) Accept(tokenHash string, admin *entity.Admin, now time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		invitation := entity.AdminInvitation{}
+		err := tx.Where("token_hash = ? AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > ?",
+			tokenHash, now).First(&invitation).Error
+		if err != nil {
+			return notFound(err)
+		}
+
+		// Update bersyarat memastikan undangan hanya dapat dipakai satu kali
+		result := tx.Model(&entity.AdminInvitation{}).
+			Where("id = ? AND accepted_at IS NULL AND revoked_at IS NULL", invitation.ID).
+			Update("accepted_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotFound
+		}
+
+		// Admin yang sudah dihapus (soft delete) tetap memegang unique index email dan username
+		var count int64
+		if err := tx.Unscoped().Model(&entity.Admin{}).
+			Where("email = ? OR username = ?", invitation.Email, admin.Username).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrConflict
+		}
+
+		admin.Email = invitation.Email
+		admin.Role = invitation.Role
+		return tx.Create(admin).Error
+	})
+}
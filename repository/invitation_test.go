@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"errors"
+	"miniproject/entity"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newInvitationTestDB membuka basis data SQLite sementara dengan tabel admin dan undangan.
+func newInvitationTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")),
+		&gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	// Kolom enum role milik admins hanya dikenal MySQL
+	err = db.Exec(`CREATE TABLE admins (
+		id integer PRIMARY KEY AUTOINCREMENT,
+		created_at datetime,
+		updated_at datetime,
+		deleted_at datetime,
+		username text NOT NULL UNIQUE,
+		email text NOT NULL UNIQUE,
+		password text NOT NULL,
+		role text DEFAULT 'admin',
+		password_reset_required numeric DEFAULT false,
+		totp_secret text,
+		totp_enabled numeric DEFAULT false,
+		totp_last_used_step integer
+	)`).Error
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	if !assert.NoError(t, db.AutoMigrate(&entity.AdminInvitation{})) {
+		t.FailNow()
+	}
+	return db
+}
+
+func TestAcceptConflictsWithDeletedAdmin(t *testing.T) {
+	db := newInvitationTestDB(t)
+	repo := NewInvitationRepository(db)
+	now := time.Now()
+
+	deleted := entity.Admin{Username: "admin2", Email: "old@example.com", Password: "hash"}
+	assert.NoError(t, db.Create(&deleted).Error)
+	assert.NoError(t, db.Delete(&deleted).Error)
+
+	invitation := entity.AdminInvitation{
+		Email:       "new@example.com",
+		Role:        "admin",
+		TokenHash:   "token",
+		ExpiresAt:   now.Add(time.Hour),
+		InvitedByID: 1,
+	}
+	assert.NoError(t, repo.Create(&invitation, now))
+
+	err := repo.Accept("token", &entity.Admin{Username: "admin2", Password: "hash"}, now)
+	assert.True(t, errors.Is(err, ErrConflict), "got %v", err)
+
+	// Undangan tidak ikut terpakai karena transaksi dibatalkan
+	stored := entity.AdminInvitation{}
+	assert.NoError(t, db.First(&stored, invitation.ID).Error)
+	assert.Nil(t, stored.AcceptedAt)
+
+	assert.NoError(t, repo.Accept("token", &entity.Admin{Username: "admin3", Password: "hash"}, now))
+}
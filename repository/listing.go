@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"miniproject/constants"
+	"miniproject/entity"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ListingFilter berisi kriteria pencarian lowongan. Field bernilai nol tidak dipakai sebagai filter.
+type ListingFilter struct {
+	// Keyword dicari pada judul, deskripsi, dan kualifikasi lowongan.
+	Keyword  string
+	Statuses []string
+	// OpenAt hanya menyertakan lowongan yang batas pendaftarannya belum lewat pada waktu tersebut.
+	OpenAt    *time.Time
+	StartFrom *time.Time
+	EndUntil  *time.Time
+	HasQuota  bool
+}
+
+// ListingCursor menandai lowongan terakhir pada halaman sebelumnya. Title dan Date hanya diisi
+// jika dipakai oleh urutan pencarian (title, deadline, atau start_date).
+type ListingCursor struct {
+	ID    uint       `json:"id"`
+	Title string     `json:"title,omitempty"`
+	Date  *time.Time `json:"date,omitempty"`
+}
+
+// ListingQuery adalah pencarian lowongan dengan urutan Sort (salah satu constants.ListingSort*)
+// dan pagination berbasis cursor.
+type ListingQuery struct {
+	ListingFilter
+	Sort  string
+	After *ListingCursor
+	Limit int
+}
+
+type ListingRepository interface {
+	Create(listing *entity.Internship_Listing) error
+	FindByID(id uint) (*entity.Internship_Listing, error)
+	FindAll() ([]entity.Internship_Listing, error)
+	// FindOpen mencari lowongan published yang batas pendaftarannya belum lewat pada waktu now.
+	FindOpen(now time.Time) ([]entity.Internship_Listing, error)
+	// Search mengembalikan satu halaman hasil pencarian beserta jumlah seluruh lowongan yang
+	// cocok dengan filter (tanpa memperhitungkan cursor dan limit).
+	Search(query ListingQuery) ([]entity.Internship_Listing, int64, error)
+	// Update hanya memperbarui field yang tidak bernilai nol pada changes.
+	Update(id uint, changes *entity.Internship_Listing) error
+	Save(listing *entity.Internship_Listing) error
+	// UpdateStatus memindahkan lowongan dari status from ke status to. Mengembalikan ErrConflict
+	// jika status lowongan sudah diubah proses lain.
+	UpdateStatus(id uint, from, to string) error
+	Delete(id uint) error
+}
+
+type listingRepository struct {
+	db *gorm.DB
+}
+
+func NewListingRepository(db *gorm.DB) ListingRepository {
+	return &listingRepository{db: db}
+}
+
+func (r *listingRepository) Create(listing *entity.Internship_Listing) error {
+	return r.db.Create(listing).Error
+}
+
+func (r *listingRepository) FindByID(id uint) (*entity.Internship_Listing, error) {
+	listing := entity.Internship_Listing{}
+	if err := r.db.First(&listing, id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &listing, nil
+}
+
+func (r *listingRepository) FindAll() ([]entity.Internship_Listing, error) {
+	var listings []entity.Internship_Listing
+	err := r.db.Find(&listings).Error
+	return listings, err
+}
+
+func (r *listingRepository) FindOpen(now time.Time) ([]entity.Internship_Listing, error) {
+	var listings []entity.Internship_Listing
+	err := r.db.Where("status = ? AND (application_deadline IS NULL OR application_deadline >= ?)", constants.ListingPublished, now).
+		Order("id").Find(&listings).Error
+	return listings, err
+}
+
+// likeEscaper meloloskan karakter wildcard LIKE agar keyword dicari apa adanya.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func (r *listingRepository) Search(query ListingQuery) ([]entity.Internship_Listing, int64, error) {
+	db := r.db.Model(&entity.Internship_Listing{})
+	filter := query.ListingFilter
+	if filter.Keyword != "" {
+		keyword := "%" + likeEscaper.Replace(filter.Keyword) + "%"
+		db = db.Where("(title LIKE ? OR description LIKE ? OR qualifications LIKE ?)", keyword, keyword, keyword)
+	}
+	if len(filter.Statuses) > 0 {
+		db = db.Where("status IN ?", filter.Statuses)
+	}
+	if filter.OpenAt != nil {
+		db = db.Where("(application_deadline IS NULL OR application_deadline >= ?)", *filter.OpenAt)
+	}
+	if filter.StartFrom != nil {
+		db = db.Where("start_date >= ?", *filter.StartFrom)
+	}
+	if filter.EndUntil != nil {
+		db = db.Where("end_date <= ?", *filter.EndUntil)
+	}
+	if filter.HasQuota {
+		db = db.Where("quota > 0")
+	}
+	// Session baru agar kondisi di atas dapat dipakai ulang untuk Count dan Find
+	db = db.Session(&gorm.Session{})
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	order, after, args := listingKeyset(query.Sort, query.After)
+	page := db.Order(order)
+	if after != "" {
+		page = page.Where(after, args...)
+	}
+	if query.Limit > 0 {
+		page = page.Limit(query.Limit)
+	}
+	var listings []entity.Internship_Listing
+	if err := page.Find(&listings).Error; err != nil {
+		return nil, 0, err
+	}
+	return listings, total, nil
+}
+
+// listingKeyset mengembalikan ORDER BY untuk urutan sort beserta kondisi untuk mengambil baris
+// setelah cursor. Tanggal kosong diletakkan di akhir dan ID menjadi pembeda nilai yang sama.
+func listingKeyset(sort string, after *ListingCursor) (string, string, []interface{}) {
+	switch sort {
+	case constants.ListingSortOldest:
+		if after == nil {
+			return "id", "", nil
+		}
+		return "id", "id > ?", []interface{}{after.ID}
+	case constants.ListingSortTitle:
+		if after == nil {
+			return "title, id", "", nil
+		}
+		return "title, id", "(title > ? OR (title = ? AND id > ?))", []interface{}{after.Title, after.Title, after.ID}
+	case constants.ListingSortDeadline, constants.ListingSortStartDate:
+		column := "start_date"
+		if sort == constants.ListingSortDeadline {
+			column = "application_deadline"
+		}
+		order := column + " IS NULL, " + column + ", id"
+		switch {
+		case after == nil:
+			return order, "", nil
+		case after.Date == nil:
+			return order, "(" + column + " IS NULL AND id > ?)", []interface{}{after.ID}
+		}
+		return order, "(" + column + " > ? OR (" + column + " = ? AND id > ?) OR " + column + " IS NULL)",
+			[]interface{}{*after.Date, *after.Date, after.ID}
+	}
+	if after == nil {
+		return "id DESC", "", nil
+	}
+	return "id DESC", "id < ?", []interface{}{after.ID}
+}
+
+func (r *listingRepository) Update(id uint, changes *entity.Internship_Listing) error {
+	return r.db.Model(&entity.Internship_Listing{}).Where("id = ?", id).Updates(changes).Error
+}
+
+func (r *listingRepository) Save(listing *entity.Internship_Listing) error {
+	return r.db.Save(listing).Error
+}
+
+func (r *listingRepository) UpdateStatus(id uint, from, to string) error {
+	result := r.db.Model(&entity.Internship_Listing{}).
+		Where("id = ? AND status = ?", id, from).
+		Update("status", to)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrConflict
+	}
+	return nil
+}
+
+func (r *listingRepository) Delete(id uint) error {
+	return r.db.Where("id = ?", id).Delete(&entity.Internship_Listing{}).Error
+}
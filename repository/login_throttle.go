@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"miniproject/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type LoginThrottleRepository interface {
+	FindByIdentifiers(identifiers []string) ([]entity.LoginThrottle, error)
+	// RecordFailure menambah hitungan gagal secara atomik. Hitungan dimulai ulang jika
+	// kegagalan terakhir lebih lama dari window.
+	RecordFailure(identifier string, now time.Time, window time.Duration) (*entity.LoginThrottle, error)
+	Lock(identifier string, until time.Time) error
+	// Delete menghapus catatan identifier. Mengembalikan false jika identifier tidak tercatat.
+	Delete(identifier string) (bool, error)
+}
+
+type loginThrottleRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginThrottleRepository(db *gorm.DB) LoginThrottleRepository {
+	return &loginThrottleRepository{db: db}
+}
+
+func (r *loginThrottleRepository) FindByIdentifiers(identifiers []string) ([]entity.LoginThrottle, error) {
+	var throttles []entity.LoginThrottle
+	err := r.db.Where("identifier IN ?", identifiers).Find(&throttles).Error
+	return throttles, err
+}
+
+func (r *loginThrottleRepository) RecordFailure(identifier string, now time.Time, window time.Duration) (*entity.LoginThrottle, error) {
+	throttle := entity.LoginThrottle{}
+	err := r.db.Where(entity.LoginThrottle{Identifier: identifier}).
+		Attrs(entity.LoginThrottle{LastFailedAt: now}).
+		FirstOrCreate(&throttle).Error
+	if err != nil {
+		return nil, err
+	}
+
+	// Increment dilakukan di basis data agar percobaan paralel tetap terhitung
+	err = r.db.Model(&entity.LoginThrottle{}).Where("id = ?", throttle.ID).Updates(map[string]interface{}{
+		"failures":       gorm.Expr("CASE WHEN last_failed_at < ? THEN 1 ELSE failures + 1 END", now.Add(-window)),
+		"last_failed_at": now,
+	}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.db.First(&throttle, throttle.ID).Error; err != nil {
+		return nil, err
+	}
+	return &throttle, nil
+}
+
+func (r *loginThrottleRepository) Lock(identifier string, until time.Time) error {
+	return r.db.Model(&entity.LoginThrottle{}).Where("identifier = ?", identifier).Update("locked_until", until).Error
+}
+
+func (r *loginThrottleRepository) Delete(identifier string) (bool, error) {
+	result := r.db.Unscoped().Where("identifier = ?", identifier).Delete(&entity.LoginThrottle{})
+	return result.RowsAffected > 0, result.Error
+}
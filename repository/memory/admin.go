@@ -0,0 +1,169 @@
+package memory
+
+import (
+	"miniproject/entity"
+	"miniproject/repository"
+	"sort"
+	"time"
+)
+
+type adminRepository struct {
+	s *store
+}
+
+func (r *adminRepository) Create(admin *entity.Admin) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.s.createAdmin(admin)
+}
+
+func (r *adminRepository) CreateFirst(admin *entity.Admin) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if len(r.s.admins) > 0 {
+		return repository.ErrConflict
+	}
+	return r.s.createAdmin(admin)
+}
+
+// createAdmin menyimpan admin baru; pemanggil harus memegang mutex.
+func (s *store) createAdmin(admin *entity.Admin) error {
+	for _, existing := range s.admins {
+		if existing.Username == admin.Username || existing.Email == admin.Email {
+			return repository.ErrConflict
+		}
+	}
+	s.newModel(&admin.Model)
+	s.admins[admin.ID] = *admin
+	return nil
+}
+
+func (r *adminRepository) FindByID(id uint) (*entity.Admin, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	admin, ok := r.s.admins[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &admin, nil
+}
+
+func (r *adminRepository) FindByUsername(username string) (*entity.Admin, error) {
+	return r.findBy(func(admin entity.Admin) bool { return admin.Username == username })
+}
+
+func (r *adminRepository) FindByEmail(email string) (*entity.Admin, error) {
+	return r.findBy(func(admin entity.Admin) bool { return admin.Email == email })
+}
+
+func (r *adminRepository) findBy(match func(entity.Admin) bool) (*entity.Admin, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	admins := make([]entity.Admin, 0, len(r.s.admins))
+	for _, admin := range r.s.admins {
+		admins = append(admins, admin)
+	}
+	sort.Slice(admins, func(i, j int) bool { return admins[i].ID < admins[j].ID })
+	for _, admin := range admins {
+		if match(admin) {
+			return &admin, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *adminRepository) Save(admin *entity.Admin) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if admin.ID == 0 {
+		r.s.newModel(&admin.Model)
+	}
+	admin.UpdatedAt = time.Now()
+	r.s.admins[admin.ID] = *admin
+	return nil
+}
+
+func (r *adminRepository) Count() (int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return int64(len(r.s.admins)), nil
+}
+
+func (r *adminRepository) UseTOTPStep(adminID uint, step int64) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	admin, ok := r.s.admins[adminID]
+	if !ok || admin.TOTPLastUsedStep >= step {
+		return false, nil
+	}
+	admin.TOTPLastUsedStep = step
+	r.s.admins[adminID] = admin
+	return true, nil
+}
+
+func (r *adminRepository) EnableTOTP(adminID uint, recoveryCodeHashes []string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	admin, ok := r.s.admins[adminID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	admin.TOTPEnabled = true
+	r.s.admins[adminID] = admin
+
+	r.s.deleteRecoveryCodes(adminID)
+	for _, hash := range recoveryCodeHashes {
+		code := entity.AdminRecoveryCode{AdminID: adminID, CodeHash: hash}
+		r.s.newModel(&code.Model)
+		r.s.recoveryCodes[code.ID] = code
+	}
+	return nil
+}
+
+func (r *adminRepository) DisableTOTP(adminID uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	admin, ok := r.s.admins[adminID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	admin.TOTPEnabled = false
+	admin.TOTPSecret = ""
+	admin.TOTPLastUsedStep = 0
+	r.s.admins[adminID] = admin
+
+	r.s.deleteRecoveryCodes(adminID)
+	return nil
+}
+
+func (s *store) deleteRecoveryCodes(adminID uint) {
+	for id, code := range s.recoveryCodes {
+		if code.AdminID == adminID {
+			delete(s.recoveryCodes, id)
+		}
+	}
+}
+
+func (r *adminRepository) UseRecoveryCode(adminID uint, codeHash string) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for id, code := range r.s.recoveryCodes {
+		if code.AdminID == adminID && code.CodeHash == codeHash && code.UsedAt == nil {
+			now := time.Now()
+			code.UsedAt = &now
+			r.s.recoveryCodes[id] = code
+			return true, nil
+		}
+	}
+	return false, nil
+}
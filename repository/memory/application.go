@@ -0,0 +1,409 @@
+package memory
+
+import (
+	"miniproject/constants"
+	"miniproject/entity"
+	"miniproject/repository"
+	"sort"
+	"time"
+)
+
+type applicationRepository struct {
+	s *store
+}
+
+func (r *applicationRepository) Create(application *entity.Internship_ApplicationForm) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.newModel(&application.Model)
+	r.s.applications[application.ID] = *application
+	return nil
+}
+
+func (r *applicationRepository) FindByID(id uint) (*entity.Internship_ApplicationForm, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	application, ok := r.s.applications[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	application.Documents = r.s.applicationDocuments(id)
+	return &application, nil
+}
+
+func (r *applicationRepository) FindAll() ([]entity.Internship_ApplicationForm, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	applications := make([]entity.Internship_ApplicationForm, 0, len(r.s.applications))
+	for _, application := range r.s.applications {
+		applications = append(applications, application)
+	}
+	sort.Slice(applications, func(i, j int) bool { return applications[i].ID < applications[j].ID })
+	return applications, nil
+}
+
+func (r *applicationRepository) Save(application *entity.Internship_ApplicationForm) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if application.ID == 0 {
+		r.s.newModel(&application.Model)
+	}
+	application.UpdatedAt = time.Now()
+	r.s.applications[application.ID] = *application
+	return nil
+}
+
+func (r *applicationRepository) Submit(application *entity.Internship_ApplicationForm, maxActive int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if r.s.hasDuplicate(application) {
+		return repository.ErrConflict
+	}
+	if err := r.s.reserveQuota(application, maxActive); err != nil {
+		return err
+	}
+
+	r.s.newModel(&application.Model)
+	r.s.applications[application.ID] = *application
+	r.s.addCandidate(application, "")
+	return nil
+}
+
+func (r *applicationRepository) CreateDraft(application *entity.Internship_ApplicationForm) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if r.s.hasDuplicate(application) {
+		return repository.ErrConflict
+	}
+	r.s.newModel(&application.Model)
+	r.s.applications[application.ID] = *application
+	return nil
+}
+
+func (r *applicationRepository) UpdateDraft(application *entity.Internship_ApplicationForm) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	stored, ok := r.s.applications[application.ID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	if stored.Status != constants.StatusDraft {
+		return repository.ErrConflict
+	}
+	copyDraftFields(&stored, application)
+	stored.UpdatedAt = time.Now()
+	r.s.applications[stored.ID] = stored
+	return nil
+}
+
+// copyDraftFields menyalin kolom yang sama dengan draftColumns pada implementasi GORM.
+func copyDraftFields(stored, application *entity.Internship_ApplicationForm) {
+	stored.Nim = application.Nim
+	stored.GPA = application.GPA
+	stored.EducationLevel = application.EducationLevel
+	stored.Answers = application.Answers
+}
+
+func (r *applicationRepository) SubmitDraft(application *entity.Internship_ApplicationForm, maxActive int) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	stored, ok := r.s.applications[application.ID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	if stored.Status != constants.StatusDraft {
+		return repository.ErrConflict
+	}
+	if err := r.s.reserveQuota(application, maxActive); err != nil {
+		return err
+	}
+
+	copyDraftFields(&stored, application)
+	stored.Status = constants.StatusPending
+	stored.UpdatedAt = time.Now()
+	r.s.applications[stored.ID] = stored
+	r.s.addCandidate(&stored, constants.StatusDraft)
+	application.Status = constants.StatusPending
+	return nil
+}
+
+// hasDuplicate harus dipanggil dengan r.s.mu terkunci.
+func (s *store) hasDuplicate(application *entity.Internship_ApplicationForm) bool {
+	for _, existing := range s.applications {
+		if existing.UserID == application.UserID && !existing.IsCanceled &&
+			existing.InternshipListingID == application.InternshipListingID {
+			return true
+		}
+	}
+	return false
+}
+
+// reserveQuota mengikuti implementasi GORM: draft tidak dihitung sebagai formulir aktif. Harus
+// dipanggil dengan r.s.mu terkunci.
+func (s *store) reserveQuota(application *entity.Internship_ApplicationForm, maxActive int) error {
+	active := 0
+	for _, existing := range s.applications {
+		if existing.UserID != application.UserID || existing.IsCanceled {
+			continue
+		}
+		if existing.Status != constants.StatusRejected && existing.Status != constants.StatusDraft {
+			active++
+		}
+	}
+	if active >= maxActive {
+		return repository.ErrLimitExceeded
+	}
+
+	listing, ok := s.listings[application.InternshipListingID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	if listing.Quota <= 0 {
+		return repository.ErrQuotaFull
+	}
+	listing.Quota--
+	listing.UpdatedAt = time.Now()
+	s.listings[listing.ID] = listing
+	return nil
+}
+
+// addCandidate mencatat kandidat dan riwayat pengajuan formulir. Harus dipanggil dengan r.s.mu
+// terkunci.
+func (s *store) addCandidate(application *entity.Internship_ApplicationForm, fromStatus string) {
+	candidate := entity.Selected_Candidate{InternshipApplicationFormID: application.ID}
+	s.newModel(&candidate.Model)
+	s.selections[candidate.ID] = candidate
+
+	s.addStatusHistory(&entity.ApplicationStatusHistory{
+		InternshipApplicationFormID: application.ID,
+		FromStatus:                  fromStatus,
+		ToStatus:                    application.Status,
+		ChangedByType:               constants.SubjectUser,
+		ChangedByID:                 application.UserID,
+	})
+}
+
+func (r *applicationRepository) Transition(application *entity.Internship_ApplicationForm, history *entity.ApplicationStatusHistory) error {
+	return r.TransitionMany([]repository.StatusChange{{Application: application, History: history}})
+}
+
+func (r *applicationRepository) TransitionMany(changes []repository.StatusChange) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	// Periksa semua perubahan lebih dulu agar tidak ada perubahan yang tersimpan sebagian
+	for _, change := range changes {
+		if err := r.s.checkTransition(change); err != nil {
+			return err
+		}
+	}
+	if err := r.s.checkSeats(changes); err != nil {
+		return err
+	}
+	for _, change := range changes {
+		r.s.applyTransition(change)
+	}
+	return nil
+}
+
+// checkSeats memastikan kandidat yang diterima dalam changes tidak melebihi kursi lowongannya
+// dan harus dipanggil dengan r.s.mu terkunci.
+func (s *store) checkSeats(changes []repository.StatusChange) error {
+	accepting := make(map[uint]int)
+	for _, change := range changes {
+		if change.History.ToStatus != constants.StatusAccepted || change.History.FromStatus == constants.StatusAccepted {
+			continue
+		}
+		listingID := s.applications[change.Application.ID].InternshipListingID
+		listing, ok := s.listings[listingID]
+		if !ok {
+			return repository.ErrNotFound
+		}
+		accepting[listingID]++
+		if s.countAccepted(listingID)+accepting[listingID] > listing.Seats {
+			return repository.ErrSeatsFull
+		}
+	}
+	return nil
+}
+
+// countAccepted harus dipanggil dengan r.s.mu terkunci.
+func (s *store) countAccepted(listingID uint) int {
+	accepted := 0
+	for _, application := range s.applications {
+		if application.InternshipListingID == listingID && application.Status == constants.StatusAccepted {
+			accepted++
+		}
+	}
+	return accepted
+}
+
+// checkTransition harus dipanggil dengan r.s.mu terkunci.
+func (s *store) checkTransition(change repository.StatusChange) error {
+	stored, ok := s.applications[change.Application.ID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	if stored.Status != change.History.FromStatus {
+		return repository.ErrConflict
+	}
+	return nil
+}
+
+// applyTransition menerapkan perubahan yang sudah diperiksa checkTransition dan harus dipanggil
+// dengan r.s.mu terkunci.
+func (s *store) applyTransition(change repository.StatusChange) {
+	stored := s.applications[change.Application.ID]
+	stored.Status = change.History.ToStatus
+	stored.IsCanceled = change.History.ToStatus == constants.StatusCanceled
+	stored.SelectionRank = change.Application.SelectionRank
+	stored.SelectionScore = change.Application.SelectionScore
+	stored.UpdatedAt = time.Now()
+	s.applications[stored.ID] = stored
+	*change.Application = stored
+
+	if change.History.FromStatus == change.History.ToStatus {
+		return
+	}
+	if stored.IsCanceled && change.History.FromStatus != constants.StatusDraft {
+		if listing, ok := s.listings[stored.InternshipListingID]; ok {
+			listing.Quota++
+			listing.UpdatedAt = time.Now()
+			s.listings[listing.ID] = listing
+		}
+	}
+	if stored.IsCanceled {
+		for id, offer := range s.offers {
+			if offer.InternshipApplicationFormID == stored.ID && offer.Status == constants.OfferPending {
+				offer.Status = constants.OfferWithdrawn
+				s.offers[id] = offer
+			}
+		}
+	}
+	if offer := change.Offer; offer != nil {
+		offer.InternshipApplicationFormID = stored.ID
+		offer.InternshipListingID = stored.InternshipListingID
+		offer.UserID = stored.UserID
+		offer.Status = constants.OfferPending
+		s.newModel(&offer.Model)
+		s.offers[offer.ID] = *offer
+	}
+	change.History.InternshipApplicationFormID = stored.ID
+	s.addStatusHistory(change.History)
+}
+
+func (r *applicationRepository) FindByListingID(listingID uint) ([]entity.Internship_ApplicationForm, error) {
+	applications, _ := r.FindAll()
+
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	result := []entity.Internship_ApplicationForm{}
+	for _, application := range applications {
+		if application.InternshipListingID == listingID {
+			application.Documents = r.s.applicationDocuments(application.ID)
+			result = append(result, application)
+		}
+	}
+	return result, nil
+}
+
+func (r *applicationRepository) FindStatusHistory(applicationID uint) ([]entity.ApplicationStatusHistory, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	history := []entity.ApplicationStatusHistory{}
+	for _, entry := range r.s.statusHistory {
+		if entry.InternshipApplicationFormID == applicationID {
+			history = append(history, entry)
+		}
+	}
+	sort.Slice(history, func(i, j int) bool { return history[i].ID < history[j].ID })
+	return history, nil
+}
+
+// addStatusHistory harus dipanggil dengan r.s.mu terkunci.
+func (s *store) addStatusHistory(history *entity.ApplicationStatusHistory) {
+	s.newModel(&history.Model)
+	s.statusHistory[history.ID] = *history
+}
+
+func (r *applicationRepository) PromoteNext(listingID uint, offer *entity.Offer, history *entity.ApplicationStatusHistory) (*entity.Internship_ApplicationForm, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	listing, ok := r.s.listings[listingID]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+
+	accepted := 0
+	var next *entity.Internship_ApplicationForm
+	for _, application := range r.s.applications {
+		if application.InternshipListingID != listingID {
+			continue
+		}
+		switch application.Status {
+		case constants.StatusAccepted:
+			accepted++
+		case constants.StatusWaitlisted:
+			if next == nil || waitlistedBefore(application, *next) {
+				candidate := application
+				next = &candidate
+			}
+		}
+	}
+	if accepted >= listing.Seats || next == nil {
+		return nil, repository.ErrNotFound
+	}
+
+	history.FromStatus = constants.StatusWaitlisted
+	history.ToStatus = constants.StatusAccepted
+	r.s.applyTransition(repository.StatusChange{Application: next, History: history, Offer: offer})
+	return next, nil
+}
+
+// waitlistedBefore mengikuti urutan daftar tunggu pada implementasi GORM: peringkat terkecil
+// lebih dulu, kandidat tanpa peringkat di akhir, lalu berdasarkan ID.
+func waitlistedBefore(a, b entity.Internship_ApplicationForm) bool {
+	if (a.SelectionRank == 0) != (b.SelectionRank == 0) {
+		return b.SelectionRank == 0
+	}
+	if a.SelectionRank != b.SelectionRank {
+		return a.SelectionRank < b.SelectionRank
+	}
+	return a.ID < b.ID
+}
+
+func (r *applicationRepository) SetAnswer(applicationID uint, answer entity.ApplicationAnswer) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	application, ok := r.s.applications[applicationID]
+	if !ok {
+		return repository.ErrNotFound
+	}
+	// Salin slice agar formulir yang sudah dikembalikan ke pemanggil tidak ikut berubah
+	answers := make([]entity.ApplicationAnswer, 0, len(application.Answers)+1)
+	replaced := false
+	for _, existing := range application.Answers {
+		if existing.Key == answer.Key {
+			existing, replaced = answer, true
+		}
+		answers = append(answers, existing)
+	}
+	if !replaced {
+		answers = append(answers, answer)
+	}
+	application.Answers = answers
+	application.UpdatedAt = time.Now()
+	r.s.applications[applicationID] = application
+	return nil
+}
@@ -0,0 +1,58 @@
+package memory
+
+import (
+	"miniproject/entity"
+	"miniproject/repository"
+	"sort"
+)
+
+type documentRepository struct {
+	s *store
+}
+
+func (r *documentRepository) Create(document *entity.ApplicationDocument) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.newModel(&document.Model)
+	r.s.documents[document.ID] = *document
+	return nil
+}
+
+func (r *documentRepository) FindByID(id uint) (*entity.ApplicationDocument, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	document, ok := r.s.documents[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &document, nil
+}
+
+func (r *documentRepository) FindByApplicationID(applicationID uint) ([]entity.ApplicationDocument, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	return r.s.applicationDocuments(applicationID), nil
+}
+
+// applicationDocuments harus dipanggil dengan r.s.mu terkunci.
+func (s *store) applicationDocuments(applicationID uint) []entity.ApplicationDocument {
+	documents := []entity.ApplicationDocument{}
+	for _, document := range s.documents {
+		if document.InternshipApplicationFormID == applicationID {
+			documents = append(documents, document)
+		}
+	}
+	sort.Slice(documents, func(i, j int) bool { return documents[i].ID < documents[j].ID })
+	return documents
+}
+
+func (r *documentRepository) Delete(id uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	delete(r.s.documents, id)
+	return nil
+}
@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"miniproject/entity"
+	"miniproject/repository"
+	"sort"
+	"time"
+)
+
+type invitationRepository struct {
+	s *store
+}
+
+func (r *invitationRepository) Create(invitation *entity.AdminInvitation, now time.Time) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for id, existing := range r.s.invitations {
+		if existing.Email == invitation.Email && existing.AcceptedAt == nil && existing.RevokedAt == nil {
+			existing.RevokedAt = &now
+			r.s.invitations[id] = existing
+		}
+	}
+	r.s.newModel(&invitation.Model)
+	r.s.invitations[invitation.ID] = *invitation
+	return nil
+}
+
+func (r *invitationRepository) FindAll() ([]entity.AdminInvitation, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	invitations := make([]entity.AdminInvitation, 0, len(r.s.invitations))
+	for _, invitation := range r.s.invitations {
+		invitations = append(invitations, invitation)
+	}
+	sort.Slice(invitations, func(i, j int) bool { return invitations[i].ID > invitations[j].ID })
+	return invitations, nil
+}
+
+func (r *invitationRepository) Revoke(id uint, now time.Time) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	invitation, ok := r.s.invitations[id]
+	if !ok || invitation.AcceptedAt != nil || invitation.RevokedAt != nil {
+		return false, nil
+	}
+	invitation.RevokedAt = &now
+	r.s.invitations[id] = invitation
+	return true, nil
+}
+
+func (r *invitationRepository) Accept(tokenHash string, admin *entity.Admin, now time.Time) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for id, invitation := range r.s.invitations {
+		if invitation.TokenHash != tokenHash || invitation.AcceptedAt != nil ||
+			invitation.RevokedAt != nil || !invitation.ExpiresAt.After(now) {
+			continue
+		}
+
+		// Seperti transaksi GORM: undangan tidak ditandai terpakai jika akun gagal dibuat
+		admin.Email = invitation.Email
+		admin.Role = invitation.Role
+		if err := r.s.createAdmin(admin); err != nil {
+			return err
+		}
+		invitation.AcceptedAt = &now
+		r.s.invitations[id] = invitation
+		return nil
+	}
+	return repository.ErrNotFound
+}
@@ -0,0 +1,239 @@
+package memory
+
+import (
+	"miniproject/constants"
+	"miniproject/entity"
+	"miniproject/repository"
+	"sort"
+	"strings"
+	"time"
+)
+
+type listingRepository struct {
+	s *store
+}
+
+func (r *listingRepository) Create(listing *entity.Internship_Listing) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	// Sama seperti default kolom status pada implementasi GORM
+	if listing.Status == "" {
+		listing.Status = constants.ListingDraft
+	}
+	r.s.newModel(&listing.Model)
+	r.s.listings[listing.ID] = *listing
+	return nil
+}
+
+func (r *listingRepository) FindByID(id uint) (*entity.Internship_Listing, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	listing, ok := r.s.listings[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &listing, nil
+}
+
+func (r *listingRepository) FindAll() ([]entity.Internship_Listing, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	listings := make([]entity.Internship_Listing, 0, len(r.s.listings))
+	for _, listing := range r.s.listings {
+		listings = append(listings, listing)
+	}
+	sort.Slice(listings, func(i, j int) bool { return listings[i].ID < listings[j].ID })
+	return listings, nil
+}
+
+func (r *listingRepository) FindOpen(now time.Time) ([]entity.Internship_Listing, error) {
+	listings, _ := r.FindAll()
+	open := []entity.Internship_Listing{}
+	for _, listing := range listings {
+		if listing.Status == constants.ListingPublished &&
+			(listing.ApplicationDeadline == nil || !listing.ApplicationDeadline.Before(now)) {
+			open = append(open, listing)
+		}
+	}
+	return open, nil
+}
+
+func (r *listingRepository) Search(query repository.ListingQuery) ([]entity.Internship_Listing, int64, error) {
+	listings, _ := r.FindAll()
+	matched := []entity.Internship_Listing{}
+	for _, listing := range listings {
+		if matchesListing(query.ListingFilter, listing) {
+			matched = append(matched, listing)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return listingBefore(query.Sort, matched[i], matched[j]) })
+
+	page := []entity.Internship_Listing{}
+	for _, listing := range matched {
+		if query.After != nil && !listingBefore(query.Sort, cursorListing(query.After), listing) {
+			continue
+		}
+		if query.Limit > 0 && len(page) == query.Limit {
+			break
+		}
+		page = append(page, listing)
+	}
+	return page, int64(len(matched)), nil
+}
+
+// matchesListing mengikuti filter pada implementasi GORM. Keyword dicocokkan tanpa membedakan
+// huruf besar dan kecil seperti collation default MySQL.
+func matchesListing(filter repository.ListingFilter, listing entity.Internship_Listing) bool {
+	if filter.Keyword != "" {
+		keyword := strings.ToLower(filter.Keyword)
+		if !strings.Contains(strings.ToLower(listing.Title), keyword) &&
+			!strings.Contains(strings.ToLower(listing.Description), keyword) &&
+			!strings.Contains(strings.ToLower(listing.Qualifications), keyword) {
+			return false
+		}
+	}
+	if len(filter.Statuses) > 0 {
+		found := false
+		for _, status := range filter.Statuses {
+			found = found || listing.Status == status
+		}
+		if !found {
+			return false
+		}
+	}
+	if filter.OpenAt != nil && listing.ApplicationDeadline != nil && listing.ApplicationDeadline.Before(*filter.OpenAt) {
+		return false
+	}
+	if filter.StartFrom != nil && (listing.StartDate == nil || listing.StartDate.Before(*filter.StartFrom)) {
+		return false
+	}
+	if filter.EndUntil != nil && (listing.EndDate == nil || listing.EndDate.After(*filter.EndUntil)) {
+		return false
+	}
+	return !filter.HasQuota || listing.Quota > 0
+}
+
+// cursorListing mengubah cursor menjadi lowongan semu agar dapat dibandingkan dengan listingBefore.
+func cursorListing(after *repository.ListingCursor) entity.Internship_Listing {
+	listing := entity.Internship_Listing{Title: after.Title, StartDate: after.Date, ApplicationDeadline: after.Date}
+	listing.ID = after.ID
+	return listing
+}
+
+// listingBefore mengikuti ORDER BY pada implementasi GORM: tanggal kosong di akhir dan ID sebagai
+// pembeda nilai yang sama.
+func listingBefore(sortBy string, a, b entity.Internship_Listing) bool {
+	switch sortBy {
+	case constants.ListingSortOldest:
+		return a.ID < b.ID
+	case constants.ListingSortTitle:
+		if a.Title != b.Title {
+			return a.Title < b.Title
+		}
+		return a.ID < b.ID
+	case constants.ListingSortDeadline:
+		return dateBefore(a.ApplicationDeadline, b.ApplicationDeadline, a.ID, b.ID)
+	case constants.ListingSortStartDate:
+		return dateBefore(a.StartDate, b.StartDate, a.ID, b.ID)
+	}
+	return a.ID > b.ID
+}
+
+func dateBefore(a, b *time.Time, aID, bID uint) bool {
+	switch {
+	case a == nil && b == nil:
+		return aID < bID
+	case a == nil || b == nil:
+		return b == nil
+	case !a.Equal(*b):
+		return a.Before(*b)
+	}
+	return aID < bID
+}
+
+func (r *listingRepository) Update(id uint, changes *entity.Internship_Listing) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	listing, ok := r.s.listings[id]
+	if !ok {
+		return nil
+	}
+	// Sama seperti Updates pada GORM, hanya field yang tidak bernilai nol yang diperbarui
+	if changes.Title != "" {
+		listing.Title = changes.Title
+	}
+	if changes.Description != "" {
+		listing.Description = changes.Description
+	}
+	if changes.Quota != 0 {
+		listing.Quota = changes.Quota
+	}
+	if changes.Seats != 0 {
+		listing.Seats = changes.Seats
+	}
+	if changes.Qualifications != "" {
+		listing.Qualifications = changes.Qualifications
+	}
+	if changes.Status != "" {
+		listing.Status = changes.Status
+	}
+	if changes.StartDate != nil {
+		listing.StartDate = changes.StartDate
+	}
+	if changes.EndDate != nil {
+		listing.EndDate = changes.EndDate
+	}
+	if changes.ApplicationDeadline != nil {
+		listing.ApplicationDeadline = changes.ApplicationDeadline
+	}
+	if changes.ScreeningCriteria != nil {
+		listing.ScreeningCriteria = changes.ScreeningCriteria
+	}
+	if changes.RankingWeights != nil {
+		listing.RankingWeights = changes.RankingWeights
+	}
+	if changes.Questions != nil {
+		listing.Questions = changes.Questions
+	}
+	listing.UpdatedAt = time.Now()
+	r.s.listings[id] = listing
+	return nil
+}
+
+func (r *listingRepository) Save(listing *entity.Internship_Listing) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if listing.ID == 0 {
+		r.s.newModel(&listing.Model)
+	}
+	listing.UpdatedAt = time.Now()
+	r.s.listings[listing.ID] = *listing
+	return nil
+}
+
+func (r *listingRepository) UpdateStatus(id uint, from, to string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	listing, ok := r.s.listings[id]
+	if !ok || listing.Status != from {
+		return repository.ErrConflict
+	}
+	listing.Status = to
+	listing.UpdatedAt = time.Now()
+	r.s.listings[id] = listing
+	return nil
+}
+
+func (r *listingRepository) Delete(id uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	delete(r.s.listings, id)
+	return nil
+}
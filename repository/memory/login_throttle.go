@@ -0,0 +1,62 @@
+package memory
+
+import (
+	"miniproject/entity"
+	"time"
+)
+
+type loginThrottleRepository struct {
+	s *store
+}
+
+func (r *loginThrottleRepository) FindByIdentifiers(identifiers []string) ([]entity.LoginThrottle, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	var throttles []entity.LoginThrottle
+	for _, identifier := range identifiers {
+		if throttle, ok := r.s.loginThrottles[identifier]; ok {
+			throttles = append(throttles, throttle)
+		}
+	}
+	return throttles, nil
+}
+
+func (r *loginThrottleRepository) RecordFailure(identifier string, now time.Time, window time.Duration) (*entity.LoginThrottle, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	throttle, ok := r.s.loginThrottles[identifier]
+	if !ok {
+		throttle = entity.LoginThrottle{Identifier: identifier, LastFailedAt: now}
+		r.s.newModel(&throttle.Model)
+	}
+	if throttle.LastFailedAt.Before(now.Add(-window)) {
+		throttle.Failures = 1
+	} else {
+		throttle.Failures++
+	}
+	throttle.LastFailedAt = now
+	r.s.loginThrottles[identifier] = throttle
+	return &throttle, nil
+}
+
+func (r *loginThrottleRepository) Lock(identifier string, until time.Time) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if throttle, ok := r.s.loginThrottles[identifier]; ok {
+		throttle.LockedUntil = &until
+		r.s.loginThrottles[identifier] = throttle
+	}
+	return nil
+}
+
+func (r *loginThrottleRepository) Delete(identifier string) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	_, ok := r.s.loginThrottles[identifier]
+	delete(r.s.loginThrottles, identifier)
+	return ok, nil
+}
@@ -0,0 +1,81 @@
+// Package memory berisi implementasi in-memory dari antarmuka di package repository.
+// Dipakai untuk unit test sehingga handler dapat diuji tanpa MySQL.
+package memory
+
+import (
+	"miniproject/entity"
+	"miniproject/repository"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// store menyimpan semua data di memori. Satu mutex untuk semua tabel sehingga operasi
+// yang menyentuh beberapa tabel tetap atomik seperti transaksi pada implementasi GORM.
+type store struct {
+	mu     sync.Mutex
+	nextID uint
+
+	users          map[uint]entity.User
+	admins         map[uint]entity.Admin
+	listings       map[uint]entity.Internship_Listing
+	applications   map[uint]entity.Internship_ApplicationForm
+	selections     map[uint]entity.Selected_Candidate
+	statusHistory  map[uint]entity.ApplicationStatusHistory
+	offers         map[uint]entity.Offer
+	documents      map[uint]entity.ApplicationDocument
+	refreshTokens  map[uint]entity.RefreshToken
+	revokedTokens  map[uint]entity.RevokedToken
+	passwordResets map[uint]entity.PasswordResetToken
+	loginThrottles map[string]entity.LoginThrottle
+	invitations    map[uint]entity.AdminInvitation
+	recoveryCodes  map[uint]entity.AdminRecoveryCode
+}
+
+// NewRepositories membuat semua repository in-memory yang berbagi satu penyimpanan.
+func NewRepositories() *repository.Repositories {
+	s := &store{
+		users:          map[uint]entity.User{},
+		admins:         map[uint]entity.Admin{},
+		listings:       map[uint]entity.Internship_Listing{},
+		applications:   map[uint]entity.Internship_ApplicationForm{},
+		selections:     map[uint]entity.Selected_Candidate{},
+		statusHistory:  map[uint]entity.ApplicationStatusHistory{},
+		offers:         map[uint]entity.Offer{},
+		documents:      map[uint]entity.ApplicationDocument{},
+		refreshTokens:  map[uint]entity.RefreshToken{},
+		revokedTokens:  map[uint]entity.RevokedToken{},
+		passwordResets: map[uint]entity.PasswordResetToken{},
+		loginThrottles: map[string]entity.LoginThrottle{},
+		invitations:    map[uint]entity.AdminInvitation{},
+		recoveryCodes:  map[uint]entity.AdminRecoveryCode{},
+	}
+	return &repository.Repositories{
+		Users:          &userRepository{s},
+		Admins:         &adminRepository{s},
+		Listings:       &listingRepository{s},
+		Applications:   &applicationRepository{s},
+		Offers:         &offerRepository{s},
+		Documents:      &documentRepository{s},
+		Selections:     &selectionRepository{s},
+		Sessions:       &sessionRepository{s},
+		PasswordResets: &passwordResetRepository{s},
+		LoginThrottles: &loginThrottleRepository{s},
+		Invitations:    &invitationRepository{s},
+	}
+}
+
+// newModel mengisi ID dan timestamp seperti yang dilakukan GORM saat Create.
+// ID yang sudah diisi pemanggil dipertahankan.
+func (s *store) newModel(model *gorm.Model) {
+	if model.ID == 0 {
+		s.nextID++
+		model.ID = s.nextID
+	} else if model.ID > s.nextID {
+		s.nextID = model.ID
+	}
+	now := time.Now()
+	model.CreatedAt = now
+	model.UpdatedAt = now
+}
@@ -0,0 +1,78 @@
+package memory
+
+import (
+	"miniproject/constants"
+	"miniproject/entity"
+	"miniproject/repository"
+	"sort"
+	"time"
+)
+
+type offerRepository struct {
+	s *store
+}
+
+func (r *offerRepository) FindByID(id uint) (*entity.Offer, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	offer, ok := r.s.offers[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &offer, nil
+}
+
+// find mengembalikan offer yang memenuhi match, diurutkan berdasarkan ID.
+func (r *offerRepository) find(match func(entity.Offer) bool) []entity.Offer {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	offers := []entity.Offer{}
+	for _, offer := range r.s.offers {
+		if match(offer) {
+			offers = append(offers, offer)
+		}
+	}
+	sort.Slice(offers, func(i, j int) bool { return offers[i].ID < offers[j].ID })
+	return offers
+}
+
+func (r *offerRepository) FindByUserID(userID uint) ([]entity.Offer, error) {
+	return r.find(func(offer entity.Offer) bool { return offer.UserID == userID }), nil
+}
+
+func (r *offerRepository) FindByListingID(listingID uint) ([]entity.Offer, error) {
+	return r.find(func(offer entity.Offer) bool { return offer.InternshipListingID == listingID }), nil
+}
+
+func (r *offerRepository) FindExpired(now time.Time) ([]entity.Offer, error) {
+	return r.find(func(offer entity.Offer) bool {
+		return offer.Status == constants.OfferPending && offer.ExpiresAt.Before(now)
+	}), nil
+}
+
+func (r *offerRepository) Respond(offer *entity.Offer, status string, now time.Time, change *repository.StatusChange) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	stored, ok := r.s.offers[offer.ID]
+	if !ok || stored.Status != constants.OfferPending {
+		return repository.ErrConflict
+	}
+	if change != nil {
+		if err := r.s.checkTransition(*change); err != nil {
+			return err
+		}
+	}
+
+	stored.Status = status
+	stored.RespondedAt = &now
+	stored.UpdatedAt = now
+	r.s.offers[stored.ID] = stored
+	*offer = stored
+	if change != nil {
+		r.s.applyTransition(*change)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package memory
+
+import (
+	"miniproject/constants"
+	"miniproject/entity"
+	"miniproject/repository"
+	"time"
+)
+
+type passwordResetRepository struct {
+	s *store
+}
+
+func (r *passwordResetRepository) Replace(token *entity.PasswordResetToken, now time.Time) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for id, existing := range r.s.passwordResets {
+		if existing.SubjectType == token.SubjectType && existing.SubjectID == token.SubjectID && existing.UsedAt == nil {
+			existing.UsedAt = &now
+			r.s.passwordResets[id] = existing
+		}
+	}
+	r.s.newModel(&token.Model)
+	r.s.passwordResets[token.ID] = *token
+	return nil
+}
+
+func (r *passwordResetRepository) ResetPassword(tokenHash, subjectType, hashedPassword string, now time.Time) (*entity.PasswordResetToken, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for id, token := range r.s.passwordResets {
+		if token.TokenHash != tokenHash || token.SubjectType != subjectType || token.UsedAt != nil || !token.ExpiresAt.After(now) {
+			continue
+		}
+
+		if subjectType == constants.SubjectAdmin {
+			admin, ok := r.s.admins[token.SubjectID]
+			if !ok {
+				return nil, repository.ErrNotFound
+			}
+			admin.Password = hashedPassword
+			admin.PasswordResetRequired = false
+			r.s.admins[admin.ID] = admin
+		} else {
+			user, ok := r.s.users[token.SubjectID]
+			if !ok {
+				return nil, repository.ErrNotFound
+			}
+			user.Password = hashedPassword
+			user.PasswordResetRequired = false
+			r.s.users[user.ID] = user
+		}
+
+		token.UsedAt = &now
+		r.s.passwordResets[id] = token
+		return &token, nil
+	}
+	return nil, repository.ErrNotFound
+}
@@ -0,0 +1,22 @@
+package memory
+
+import (
+	"miniproject/entity"
+	"miniproject/repository"
+)
+
+type selectionRepository struct {
+	s *store
+}
+
+func (r *selectionRepository) Create(candidate *entity.Selected_Candidate) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if _, exists := r.s.selections[candidate.ID]; exists && candidate.ID != 0 {
+		return repository.ErrConflict
+	}
+	r.s.newModel(&candidate.Model)
+	r.s.selections[candidate.ID] = *candidate
+	return nil
+}
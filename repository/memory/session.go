@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"miniproject/entity"
+	"miniproject/repository"
+	"time"
+)
+
+type sessionRepository struct {
+	s *store
+}
+
+func (r *sessionRepository) CreateRefreshToken(token *entity.RefreshToken) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.newModel(&token.Model)
+	r.s.refreshTokens[token.ID] = *token
+	return nil
+}
+
+func (r *sessionRepository) FindRefreshToken(tokenHash string) (*entity.RefreshToken, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, token := range r.s.refreshTokens {
+		if token.TokenHash == tokenHash {
+			return &token, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *sessionRepository) RotateRefreshToken(currentID uint, next *entity.RefreshToken, now time.Time) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	current, ok := r.s.refreshTokens[currentID]
+	if !ok || current.RevokedAt != nil {
+		return false, nil
+	}
+	current.RevokedAt = &now
+	r.s.refreshTokens[currentID] = current
+
+	r.s.newModel(&next.Model)
+	r.s.refreshTokens[next.ID] = *next
+	return true, nil
+}
+
+func (r *sessionRepository) RevokeRefreshFamily(familyID string, now time.Time) error {
+	r.revokeWhere(now, func(token entity.RefreshToken) bool { return token.FamilyID == familyID })
+	return nil
+}
+
+func (r *sessionRepository) RevokeSubjectRefreshTokens(subjectType string, subjectID uint, now time.Time) error {
+	r.revokeWhere(now, func(token entity.RefreshToken) bool {
+		return token.SubjectType == subjectType && token.SubjectID == subjectID
+	})
+	return nil
+}
+
+func (r *sessionRepository) revokeWhere(now time.Time, match func(entity.RefreshToken) bool) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for id, token := range r.s.refreshTokens {
+		if token.RevokedAt == nil && match(token) {
+			token.RevokedAt = &now
+			r.s.refreshTokens[id] = token
+		}
+	}
+}
+
+func (r *sessionRepository) CreateRevokedToken(token *entity.RevokedToken) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	r.s.newModel(&token.Model)
+	r.s.revokedTokens[token.ID] = *token
+	return nil
+}
+
+func (r *sessionRepository) IsTokenRevoked(jti, subjectType string, subjectID uint, issuedAt, now time.Time) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, token := range r.s.revokedTokens {
+		if !token.ExpiresAt.After(now) {
+			continue
+		}
+		if token.JTI != "" && token.JTI == jti {
+			return true, nil
+		}
+		if token.JTI == "" && token.SubjectType == subjectType && token.SubjectID == subjectID &&
+			!token.CreatedAt.Before(issuedAt) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"miniproject/entity"
+	"miniproject/repository"
+	"sort"
+	"time"
+)
+
+type userRepository struct {
+	s *store
+}
+
+func (r *userRepository) Create(user *entity.User) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	for _, existing := range r.s.users {
+		if existing.Username == user.Username || existing.Email == user.Email {
+			return repository.ErrConflict
+		}
+	}
+	r.s.newModel(&user.Model)
+	r.s.users[user.ID] = *user
+	return nil
+}
+
+func (r *userRepository) FindByID(id uint) (*entity.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	user, ok := r.s.users[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &user, nil
+}
+
+func (r *userRepository) FindByUsername(username string) (*entity.User, error) {
+	return r.findBy(func(user entity.User) bool { return user.Username == username })
+}
+
+func (r *userRepository) FindByEmail(email string) (*entity.User, error) {
+	return r.findBy(func(user entity.User) bool { return user.Email == email })
+}
+
+func (r *userRepository) findBy(match func(entity.User) bool) (*entity.User, error) {
+	users, _ := r.FindAll()
+	for _, user := range users {
+		if match(user) {
+			return &user, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (r *userRepository) FindAll() ([]entity.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	users := make([]entity.User, 0, len(r.s.users))
+	for _, user := range r.s.users {
+		users = append(users, user)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+	return users, nil
+}
+
+func (r *userRepository) Save(user *entity.User) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	if user.ID == 0 {
+		r.s.newModel(&user.Model)
+	}
+	user.UpdatedAt = time.Now()
+	r.s.users[user.ID] = *user
+	return nil
+}
+
+func (r *userRepository) Delete(user *entity.User) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+
+	delete(r.s.users, user.ID)
+	return nil
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"miniproject/constants"
+	"miniproject/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type OfferRepository interface {
+	FindByID(id uint) (*entity.Offer, error)
+	FindByUserID(userID uint) ([]entity.Offer, error)
+	FindByListingID(listingID uint) ([]entity.Offer, error)
+	// FindExpired mencari offer yang belum dijawab sampai melewati ExpiresAt.
+	FindExpired(now time.Time) ([]entity.Offer, error)
+	// Respond mengubah offer yang belum dijawab ke status dan menerapkan change (jika diisi) dalam
+	// satu transaksi, misalnya pembatalan formulir saat offer ditolak. Mengembalikan ErrConflict
+	// jika offer sudah dijawab, kedaluwarsa, atau ditarik.
+	Respond(offer *entity.Offer, status string, now time.Time, change *StatusChange) error
+}
+
+type offerRepository struct {
+	db *gorm.DB
+}
+
+func NewOfferRepository(db *gorm.DB) OfferRepository {
+	return &offerRepository{db: db}
+}
+
+func (r *offerRepository) FindByID(id uint) (*entity.Offer, error) {
+	offer := entity.Offer{}
+	if err := r.db.First(&offer, id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &offer, nil
+}
+
+func (r *offerRepository) FindByUserID(userID uint) ([]entity.Offer, error) {
+	var offers []entity.Offer
+	err := r.db.Where("user_id = ?", userID).Order("id").Find(&offers).Error
+	return offers, err
+}
+
+func (r *offerRepository) FindByListingID(listingID uint) ([]entity.Offer, error) {
+	var offers []entity.Offer
+	err := r.db.Where("internship_listing_id = ?", listingID).Order("id").Find(&offers).Error
+	return offers, err
+}
+
+func (r *offerRepository) FindExpired(now time.Time) ([]entity.Offer, error) {
+	var offers []entity.Offer
+	err := r.db.Where("status = ? AND expires_at < ?", constants.OfferPending, now).Order("id").Find(&offers).Error
+	return offers, err
+}
+
+func (r *offerRepository) Respond(offer *entity.Offer, status string, now time.Time, change *StatusChange) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.Offer{}).
+			Where("id = ? AND status = ?", offer.ID, constants.OfferPending).
+			Updates(map[string]interface{}{"status": status, "responded_at": now})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrConflict
+		}
+		if change == nil {
+			return nil
+		}
+		return transition(tx, *change)
+	})
+	if err != nil {
+		return err
+	}
+
+	offer.Status = status
+	offer.RespondedAt = &now
+	if change != nil {
+		change.Application.Status = change.History.ToStatus
+		change.Application.IsCanceled = change.History.ToStatus == constants.StatusCanceled
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"miniproject/constants"
+	"miniproject/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PasswordResetRepository interface {
+	// Replace menyimpan token baru dan membatalkan token lama subject yang belum dipakai.
+	Replace(token *entity.PasswordResetToken, now time.Time) error
+	// ResetPassword memakai token (sekali pakai) lalu mengganti password subject-nya dalam satu
+	// transaksi. Mengembalikan ErrNotFound jika token tidak valid, sudah dipakai, atau kedaluwarsa.
+	ResetPassword(tokenHash, subjectType, hashedPassword string, now time.Time) (*entity.PasswordResetToken, error)
+}
+
+type passwordResetRepository struct {
+	db *gorm.DB
+}
+
+func NewPasswordResetRepository(db *gorm.DB) PasswordResetRepository {
+	return &passwordResetRepository{db: db}
+}
+
+func (r *passwordResetRepository) Replace(token *entity.PasswordResetToken, now time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&entity.PasswordResetToken{}).
+			Where("subject_type = ? AND subject_id = ? AND used_at IS NULL", token.SubjectType, token.SubjectID).
+			Update("used_at", now).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(token).Error
+	})
+}
+
+func (r *passwordResetRepository) ResetPassword(tokenHash, subjectType, hashedPassword string, now time.Time) (*entity.PasswordResetToken, error) {
+	resetToken := entity.PasswordResetToken{}
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("token_hash = ? AND subject_type = ? AND used_at IS NULL AND expires_at > ?",
+			tokenHash, subjectType, now).First(&resetToken).Error
+		if err != nil {
+			return notFound(err)
+		}
+
+		// Update bersyarat memastikan token hanya dapat dipakai satu kali
+		result := tx.Model(&entity.PasswordResetToken{}).
+			Where("id = ? AND used_at IS NULL", resetToken.ID).
+			Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotFound
+		}
+
+		var model interface{} = &entity.User{}
+		if subjectType == constants.SubjectAdmin {
+			model = &entity.Admin{}
+		}
+		result = tx.Model(model).Where("id = ?", resetToken.SubjectID).Updates(map[string]interface{}{
+			"password":                hashedPassword,
+			"password_reset_required": false,
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &resetToken, nil
+}
@@ -0,0 +1,63 @@
+// Package repository berisi antarmuka akses data beserta implementasi GORM-nya.
+// Handler dan middleware hanya bergantung pada antarmuka di sini sehingga dapat
+// diuji dengan implementasi in-memory dari package repository/memory.
+package repository
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrNotFound dikembalikan jika data yang dicari tidak ada (atau token tidak lagi berlaku).
+	ErrNotFound = errors.New("record not found")
+	// ErrConflict dikembalikan jika data bentrok dengan data lain yang sudah ada.
+	ErrConflict = errors.New("record already exists")
+	// ErrQuotaFull dikembalikan jika kuota lowongan sudah habis saat formulir diajukan.
+	ErrQuotaFull = errors.New("listing quota exhausted")
+	// ErrLimitExceeded dikembalikan jika batas jumlah data aktif milik pengguna sudah tercapai.
+	ErrLimitExceeded = errors.New("active limit exceeded")
+	// ErrSeatsFull dikembalikan jika kandidat diterima saat semua kursi lowongan sudah terisi.
+	ErrSeatsFull = errors.New("listing seats full")
+)
+
+// Repositories mengelompokkan semua repository yang dipakai aplikasi.
+type Repositories struct {
+	Users          UserRepository
+	Admins         AdminRepository
+	Listings       ListingRepository
+	Applications   ApplicationRepository
+	Offers         OfferRepository
+	Documents      DocumentRepository
+	Selections     SelectionRepository
+	Sessions       SessionRepository
+	PasswordResets PasswordResetRepository
+	LoginThrottles LoginThrottleRepository
+	Invitations    InvitationRepository
+}
+
+// NewGormRepositories membuat semua repository berbasis GORM untuk koneksi db.
+func NewGormRepositories(db *gorm.DB) *Repositories {
+	return &Repositories{
+		Users:          NewUserRepository(db),
+		Admins:         NewAdminRepository(db),
+		Listings:       NewListingRepository(db),
+		Applications:   NewApplicationRepository(db),
+		Offers:         NewOfferRepository(db),
+		Documents:      NewDocumentRepository(db),
+		Selections:     NewSelectionRepository(db),
+		Sessions:       NewSessionRepository(db),
+		PasswordResets: NewPasswordResetRepository(db),
+		LoginThrottles: NewLoginThrottleRepository(db),
+		Invitations:    NewInvitationRepository(db),
+	}
+}
+
+// notFound menerjemahkan gorm.ErrRecordNotFound menjadi ErrNotFound.
+func notFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
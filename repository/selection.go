@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"miniproject/entity"
+
+	"gorm.io/gorm"
+)
+
+type SelectionRepository interface {
+	Create(candidate *entity.Selected_Candidate) error
+}
+
+type selectionRepository struct {
+	db *gorm.DB
+}
+
+func NewSelectionRepository(db *gorm.DB) SelectionRepository {
+	return &selectionRepository{db: db}
+}
+
+func (r *selectionRepository) Create(candidate *entity.Selected_Candidate) error {
+	return r.db.Create(candidate).Error
+}
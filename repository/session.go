@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"miniproject/entity"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type SessionRepository interface {
+	CreateRefreshToken(token *entity.RefreshToken) error
+	FindRefreshToken(tokenHash string) (*entity.RefreshToken, error)
+	// RotateRefreshToken mencabut token currentID dan menyimpan next dalam satu transaksi.
+	// Mengembalikan false jika token lama sudah dicabut oleh request lain.
+	RotateRefreshToken(currentID uint, next *entity.RefreshToken, now time.Time) (bool, error)
+	RevokeRefreshFamily(familyID string, now time.Time) error
+	RevokeSubjectRefreshTokens(subjectType string, subjectID uint, now time.Time) error
+
+	CreateRevokedToken(token *entity.RevokedToken) error
+	// IsTokenRevoked mengecek denylist berdasarkan jti maupun pencabutan seluruh sesi subject
+	// yang terjadi setelah issuedAt.
+	IsTokenRevoked(jti, subjectType string, subjectID uint, issuedAt, now time.Time) (bool, error)
+}
+
+type sessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) SessionRepository {
+	return &sessionRepository{db: db}
+}
+
+func (r *sessionRepository) CreateRefreshToken(token *entity.RefreshToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *sessionRepository) FindRefreshToken(tokenHash string) (*entity.RefreshToken, error) {
+	token := entity.RefreshToken{}
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &token, nil
+}
+
+func (r *sessionRepository) RotateRefreshToken(currentID uint, next *entity.RefreshToken, now time.Time) (bool, error) {
+	rotated := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Update bersyarat mencegah dua request paralel merotasi token yang sama
+		result := tx.Model(&entity.RefreshToken{}).
+			Where("id = ? AND revoked_at IS NULL", currentID).
+			Update("revoked_at", now)
+		if result.Error != nil || result.RowsAffected == 0 {
+			return result.Error
+		}
+		if err := tx.Create(next).Error; err != nil {
+			return err
+		}
+		rotated = true
+		return nil
+	})
+	return rotated, err
+}
+
+func (r *sessionRepository) RevokeRefreshFamily(familyID string, now time.Time) error {
+	return r.db.Model(&entity.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", now).Error
+}
+
+func (r *sessionRepository) RevokeSubjectRefreshTokens(subjectType string, subjectID uint, now time.Time) error {
+	return r.db.Model(&entity.RefreshToken{}).
+		Where("subject_type = ? AND subject_id = ? AND revoked_at IS NULL", subjectType, subjectID).
+		Update("revoked_at", now).Error
+}
+
+func (r *sessionRepository) CreateRevokedToken(token *entity.RevokedToken) error {
+	return r.db.Create(token).Error
+}
+
+func (r *sessionRepository) IsTokenRevoked(jti, subjectType string, subjectID uint, issuedAt, now time.Time) (bool, error) {
+	var count int64
+	err := r.db.Model(&entity.RevokedToken{}).
+		Where("expires_at > ?", now).
+		Where(r.db.Where("jti = ? AND jti <> ''", jti).
+			Or("jti = '' AND subject_type = ? AND subject_id = ? AND created_at >= ?", subjectType, subjectID, issuedAt)).
+		Count(&count).Error
+	return count > 0, err
+}
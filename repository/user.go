@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"miniproject/entity"
+
+	"gorm.io/gorm"
+)
+
+type UserRepository interface {
+	Create(user *entity.User) error
+	FindByID(id uint) (*entity.User, error)
+	FindByUsername(username string) (*entity.User, error)
+	FindByEmail(email string) (*entity.User, error)
+	FindAll() ([]entity.User, error)
+	Save(user *entity.User) error
+	Delete(user *entity.User) error
+}
+
+type userRepository struct {
+	db *gorm.DB
+}
+
+func NewUserRepository(db *gorm.DB) UserRepository {
+	return &userRepository{db: db}
+}
+
+func (r *userRepository) Create(user *entity.User) error {
+	return r.db.Create(user).Error
+}
+
+func (r *userRepository) FindByID(id uint) (*entity.User, error) {
+	user := entity.User{}
+	if err := r.db.First(&user, id).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &user, nil
+}
+
+func (r *userRepository) FindByUsername(username string) (*entity.User, error) {
+	user := entity.User{}
+	if err := r.db.Where("username = ?", username).First(&user).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &user, nil
+}
+
+func (r *userRepository) FindByEmail(email string) (*entity.User, error) {
+	user := entity.User{}
+	if err := r.db.Where("email = ?", email).First(&user).Error; err != nil {
+		return nil, notFound(err)
+	}
+	return &user, nil
+}
+
+func (r *userRepository) FindAll() ([]entity.User, error) {
+	var users []entity.User
+	err := r.db.Find(&users).Error
+	return users, err
+}
+
+func (r *userRepository) Save(user *entity.User) error {
+	return r.db.Save(user).Error
+}
+
+func (r *userRepository) Delete(user *entity.User) error {
+	return r.db.Delete(user).Error
+}
@@ -1,15 +1,23 @@
 package routes
 
 import (
+	"context"
+	"miniproject/constants"
 	"miniproject/controllers"
+	"miniproject/helpers"
+	"miniproject/infra/storage"
 	"miniproject/internships/handler"
 	"miniproject/internships/usecase"
 	"miniproject/middleware"
+	"miniproject/repository"
+	"net/http"
 
 	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 )
 
-func InitmyRoutes() *echo.Echo {
+func InitmyRoutes(db *gorm.DB) *echo.Echo {
 	e := echo.New()
 	internshipUsecase := usecase.NewInternshipApplicationUsecase()
 	internshipHandler := handler.NewInternshipHandler(internshipUsecase)
@@ -17,33 +25,99 @@ func InitmyRoutes() *echo.Echo {
 	
 	middleware.LogMiddleware(e)
 
+	repos := repository.NewGormRepositories(db)
+	auth := middleware.NewAuth(repos.Users, repos.Admins, repos.Sessions)
+	throttle := helpers.NewLoginThrottler(repos.LoginThrottles)
+	authHandler := controllers.NewAuthHandler(repos, auth)
+
+	// Dokumen kandidat disimpan di backend yang dipilih lewat STORAGEBACKEND
+	store, err := storage.NewFromEnv(context.Background(), helpers.AppURL("/files"))
+	if err != nil {
+		logrus.Fatal("Storage : ", err.Error())
+	}
+	if local, ok := store.(*storage.Local); ok {
+		// Backend lokal melayani unduhan sendiri; keabsahan URL diperiksa dari tanda tangannya
+		e.GET("/files/*", echo.WrapHandler(http.StripPrefix("/files", local)))
+	}
+
+	adminHandler := controllers.NewAdminHandler(repos, store, auth, throttle)
+	userHandler := controllers.NewUserHandler(repos, store, auth, throttle)
+
+	// Setiap rute terproteksi memuat principal sekali lewat RequireRole
+	adminOnly := []echo.MiddlewareFunc{auth.JWTMiddleware(), auth.RequireRole(constants.RoleAdmin)}
+	userOnly := []echo.MiddlewareFunc{auth.JWTMiddleware(), auth.RequireRole(constants.RoleUser)}
+	userOrAdmin := []echo.MiddlewareFunc{auth.JWTMiddleware(), auth.RequireRole(constants.RoleUser, constants.RoleAdmin)}
+
+	// Rute sesi (refresh token & logout)
+	authGroup := e.Group("/auth")
+	authGroup.POST("/refresh", authHandler.RefreshTokenController)
+	authGroup.POST("/logout", authHandler.LogoutController, userOrAdmin...)
+
 	// Rute-rute admin
 	adminGroup := e.Group("/admin")
-	adminGroup.POST("/register", controllers.RegisterAdmin)
-	adminGroup.POST("/login", controllers.LoginAdminController)
-	adminGroup.GET("/:id", controllers.GetAdminByID, middleware.JWTMiddleware())
-	adminGroup.PUT("/:id", controllers.UpdateAdminController, middleware.JWTMiddleware())
+	adminGroup.POST("/register", adminHandler.RegisterAdmin)
+	adminGroup.POST("/login", adminHandler.LoginAdminController)
+	adminGroup.POST("/login/2fa", adminHandler.VerifyTwoFactorLoginController)
+	adminGroup.POST("/invitations/accept", adminHandler.AcceptAdminInvitationController)
+	adminGroup.POST("/password/forgot", adminHandler.ForgotAdminPasswordController)
+	adminGroup.GET("/password/reset", adminHandler.ResetAdminPasswordFormController)
+	adminGroup.POST("/password/reset", adminHandler.ResetAdminPasswordController)
+	adminGroup.GET("/:id", adminHandler.GetAdminByID, adminOnly...)
+	adminGroup.PUT("/:id", adminHandler.UpdateAdminController, adminOnly...)
+	adminGroup.POST("/unlock-account", adminHandler.UnlockAccountController, adminOnly...)
+	adminGroup.POST("/2fa/enroll", adminHandler.EnrollTwoFactorController, adminOnly...)
+	adminGroup.POST("/2fa/confirm", adminHandler.ConfirmTwoFactorController, adminOnly...)
+	adminGroup.POST("/2fa/disable", adminHandler.DisableTwoFactorController, adminOnly...)
+	adminGroup.POST("/invitations", adminHandler.CreateAdminInvitationController, adminOnly...)
+	adminGroup.GET("/invitations", adminHandler.GetAdminInvitationsController, adminOnly...)
+	adminGroup.DELETE("/invitations/:id", adminHandler.RevokeAdminInvitationController, adminOnly...)
 	// internship admin
-	adminGroup.POST("/internship", controllers.CreateInternshipListing, middleware.JWTMiddleware())
-	adminGroup.PUT("/internship/:id", controllers.UpdateInternshipListingByID, middleware.JWTMiddleware())
-	adminGroup.DELETE("/internship/:id", controllers.DeleteInternshipListingByID, middleware.JWTMiddleware())
-	adminGroup.GET("/selected-candidates/:id", controllers.SelectCandidatesByGPAID, middleware.JWTMiddleware())
-	adminGroup.GET("/candidates", controllers.ViewAllCandidates, middleware.JWTMiddleware()) 
-	adminGroup.POST("/email", controllers.SendEmailHandler, middleware.JWTMiddleware())
+	adminGroup.POST("/internship", adminHandler.CreateInternshipListing, adminOnly...)
+	adminGroup.PUT("/internship/:id", adminHandler.UpdateInternshipListingByID, adminOnly...)
+	adminGroup.DELETE("/internship/:id", adminHandler.DeleteInternshipListingByID, adminOnly...)
+	adminGroup.PUT("/internship/:id/status", adminHandler.ChangeInternshipListingStatus, adminOnly...)
+	adminGroup.POST("/internship/:id/selection-run", adminHandler.SelectionRunController, adminOnly...)
+	adminGroup.GET("/internship/:id/offers", adminHandler.GetListingOffersController, adminOnly...)
+	adminGroup.GET("/selected-candidates/:id", adminHandler.ScreenCandidateByID, adminOnly...)
+	adminGroup.GET("/candidates", adminHandler.ViewAllCandidates, adminOnly...)
+	adminGroup.PUT("/applications/:id/status", adminHandler.TransitionApplicationStatusController, adminOnly...)
+	adminGroup.GET("/applications/:id/history", adminHandler.GetApplicationStatusHistoryController, adminOnly...)
+	adminGroup.GET("/applications/:id/screening", adminHandler.PreviewScreeningByID, adminOnly...)
+	adminGroup.GET("/applications/:id/documents", adminHandler.GetApplicationDocumentLinksController, adminOnly...)
+	adminGroup.POST("/email", adminHandler.SendEmailHandler, adminOnly...)
+
+	// Route untuk lowongan magang
+	listingGroup := e.Group("/listings")
+	listingGroup.GET("", userHandler.GetInternshipListings, userOrAdmin...)
+	listingGroup.GET("/:id", userHandler.GetInternshipListingByID, userOrAdmin...)
 
 	// Route untuk User
 	userGroup := e.Group("/users")
-	userGroup.POST("/register", controllers.RegisterUser)
-	userGroup.POST("/login", controllers.LoginUserController)
-	userGroup.GET("/all", controllers.GetAllUsers, middleware.JWTMiddleware())
-	userGroup.GET("/:id", controllers.GetUserByID, middleware.JWTMiddleware())
-	userGroup.PUT("/:id", controllers.UpdateUserByID, middleware.JWTMiddleware())
-	userGroup.DELETE("/:id", controllers.DeleteUser, middleware.JWTMiddleware())
+	userGroup.POST("/register", userHandler.RegisterUser)
+	userGroup.POST("/login", userHandler.LoginUserController)
+	userGroup.GET("/verify", userHandler.VerifyEmailController)
+	userGroup.POST("/password/forgot", userHandler.ForgotUserPasswordController)
+	userGroup.GET("/password/reset", userHandler.ResetUserPasswordFormController)
+	userGroup.POST("/password/reset", userHandler.ResetUserPasswordController)
+	userGroup.POST("/verify/resend", userHandler.ResendVerificationEmailController, userOnly...)
+	userGroup.GET("/all", userHandler.GetAllUsers, adminOnly...)
+	userGroup.GET("/:id", userHandler.GetUserByID, userOrAdmin...)
+	userGroup.PUT("/:id", userHandler.UpdateUserByID, userOrAdmin...)
+	userGroup.DELETE("/:id", userHandler.DeleteUser, userOrAdmin...)
 	// internship user
-	userGroup.GET("/internship-listings", controllers.GetInternshipListings, middleware.JWTMiddleware())
-	userGroup.POST("/apply-for-internship", controllers.ApplyForInternship, middleware.JWTMiddleware())
-	userGroup.DELETE("/apply-for-internship/:id", controllers.CancelApplication, middleware.JWTMiddleware())
-	userGroup.GET("/candidates", controllers.ViewAllCandidates, middleware.JWTMiddleware())
-	userGroup.GET("/Application-Status/:id", controllers.GetApplicationStatus, middleware.JWTMiddleware())
+	userGroup.GET("/internship-listings", userHandler.GetInternshipListings, userOrAdmin...)
+	userGroup.POST("/apply-for-internship", userHandler.ApplyForInternship, userOnly...)
+	userGroup.DELETE("/apply-for-internship/:id", userHandler.CancelApplication, userOrAdmin...)
+	userGroup.POST("/apply-for-internship/drafts", userHandler.CreateDraftController, userOnly...)
+	userGroup.PATCH("/apply-for-internship/:id", userHandler.UpdateDraftController, userOnly...)
+	userGroup.POST("/apply-for-internship/:id/submit", userHandler.SubmitDraftController, userOnly...)
+	userGroup.POST("/apply-for-internship/:id/documents", userHandler.UploadDocumentController, userOnly...)
+	userGroup.GET("/apply-for-internship/:id/documents", userHandler.GetApplicationDocumentsController, userOrAdmin...)
+	userGroup.POST("/apply-for-internship/:id/answers/:key", userHandler.UploadAnswerController, userOnly...)
+	userGroup.GET("/offers", userHandler.GetMyOffersController, userOnly...)
+	userGroup.POST("/offers/:id/accept", userHandler.AcceptOfferController, userOnly...)
+	userGroup.POST("/offers/:id/decline", userHandler.DeclineOfferController, userOnly...)
+	userGroup.GET("/candidates", adminHandler.ViewAllCandidates, adminOnly...)
+	userGroup.GET("/Application-Status/:id", userHandler.GetApplicationStatus, userOrAdmin...)
 	return e
 }